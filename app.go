@@ -2,8 +2,8 @@ package domui
 
 import (
 	"reflect"
+	"strings"
 	"sync"
-	"syscall/js"
 	"time"
 
 	"github.com/reusee/dscope"
@@ -12,54 +12,156 @@ import (
 type RootElement Spec
 
 type App struct {
-	wrapElement js.Value
-	element     js.Value
+	dom         DOM
+	wrapElement DOMNode
+	element     DOMNode
 	dirty       chan struct{}
 	rootNode    *Node
 	scope       dscope.Scope
 	scopeLock   sync.Mutex
+	hydrating   bool
+	closeOnce   sync.Once
+	closed      chan struct{}
+	// events
+	eventsLock       sync.RWMutex
+	eventRegistry    map[int32]map[string][]EventSpec // element id: event type: specs
+	listeners        map[listenerKey]func()           // remove listener
+	onceCalled       map[int32]map[specKey]bool       // element id: called Once specs
+	globalRegistry   map[int32]globalHandlers         // element id: window and document handlers
+	shortcuts        map[int32]shortcutEntry          // element id: shortcuts
+	limiters         map[int32]map[specKey]*limiter   // element id: rate limiters
+	portals          map[int32]*portal                // placeholder element id: portal
+	portalContainers map[int32]*portal                // container element id: portal
+	clock            Clock
+	// lifecycle
+	unmountHooks map[int32][]any // element id: hooks
+	pendingHooks []func()
+	// router
+	history     History
+	stopHistory func()
+	// resources
+	resources   map[resourceKey]*resourceEntry
+	resourceGen uint64
 }
 
-func NewApp(
-	renderElement js.Value,
+// NewDOMApp creates an App rendering to renderElement of dom
+func NewDOMApp(
+	dom DOM,
+	renderElement DOMNode,
 	defs ...any,
 ) *App {
 
 	app := &App{
-		dirty: make(chan struct{}, 1),
+		dom:              dom,
+		dirty:            make(chan struct{}, 1),
+		closed:           make(chan struct{}),
+		eventRegistry:    make(map[int32]map[string][]EventSpec),
+		listeners:        make(map[listenerKey]func()),
+		onceCalled:       make(map[int32]map[specKey]bool),
+		globalRegistry:   make(map[int32]globalHandlers),
+		shortcuts:        make(map[int32]shortcutEntry),
+		limiters:         make(map[int32]map[specKey]*limiter),
+		portals:          make(map[int32]*portal),
+		portalContainers: make(map[int32]*portal),
+		unmountHooks:     make(map[int32][]any),
+		resources:        make(map[resourceKey]*resourceEntry),
 	}
 
 	defs = append(
 		defs,
+		func() DOM {
+			return dom
+		},
 		func() Update {
 			return app.Update
 		},
+		func() Navigate {
+			return app.Navigate
+		},
 		func() *App {
 			return app
 		},
 	)
 
-	app.scope = dscope.New(defs...)
-
-	app.scope = app.scope.Fork(
+	// user definitions override the defaults
+	app.scope = dscope.New(
 		dscope.Methods(new(Def))...,
+	).Fork(
+		defs...,
 	)
 
-	parentElement := js.Value(renderElement)
-	parentElement.Set("innerHTML", "")
-	wrap := document.Call("createElement", "div")
-	parentElement.Call("appendChild", wrap)
-	element := document.Call("createElement", "div")
-	wrap.Call("appendChild", element)
+	var hydrate Hydrate
+	app.scope.Assign(&hydrate, &app.clock, &app.history)
+
+	// location from history, if not defined
+	if !definesLocation(defs) {
+		location := Location(app.history.Location())
+		app.scope = app.scope.Fork(func() Location {
+			return location
+		})
+	}
+	app.stopHistory = app.history.Listen(func() {
+		app.setLocation(Location(app.history.Location()))
+	})
+
+	parentElement := renderElement
+	wrap := dom.CreateElement("div")
+	if hydrate {
+		// adopt the children as the root elements, extra ones are dropped in hydration
+		for parentElement.NumChildNodes() > 0 {
+			wrap.AppendChild(parentElement.ChildNode(0))
+		}
+		parentElement.AppendChild(wrap)
+		app.element = wrap.ChildNode(0)
+		app.hydrating = true
+	} else {
+		for n := parentElement.NumChildNodes(); n > 0; n-- {
+			parentElement.ChildNode(n - 1).Remove()
+		}
+		parentElement.AppendChild(wrap)
+		element := dom.CreateElement("div")
+		wrap.AppendChild(element)
+		app.element = element
+	}
 	app.wrapElement = wrap
-	app.element = element
 
 	go func() {
 		for {
 			select {
 
+			case <-app.closed:
+				return
+
 			case <-app.dirty:
-				app.Render()
+				app.scopeLock.Lock()
+				var mode RenderMode
+				app.scope.Assign(&mode)
+				app.scopeLock.Unlock()
+
+				switch mode {
+
+				case RenderOnAnimationFrame:
+					frame := make(chan struct{})
+					dom.RequestAnimationFrame(func() {
+						// not rendering in the callback, which must not block the javascript event loop
+						close(frame)
+					})
+					select {
+					case <-frame:
+					case <-app.closed:
+						return
+					}
+					// updates before the frame are rendered in this frame
+					select {
+					case <-app.dirty:
+					default:
+					}
+					app.Render()
+
+				case RenderImmediately:
+					app.Render()
+
+				}
 
 			}
 		}
@@ -80,6 +182,20 @@ func (a *App) Update(defs ...any) {
 	}
 }
 
+// RenderMode controls when the App renders after updates
+type RenderMode uint8
+
+const (
+	// coalesce updates and render after requestAnimationFrame fires
+	RenderOnAnimationFrame RenderMode = iota
+	// render as soon as updated
+	RenderImmediately
+)
+
+func (_ Def) RenderMode() RenderMode {
+	return RenderOnAnimationFrame
+}
+
 var rootElementType = reflect.TypeOf((*RootElement)(nil)).Elem()
 
 type SlowRenderThreshold time.Duration
@@ -89,6 +205,33 @@ func (_ Def) SlowRenderThreshold() SlowRenderThreshold {
 }
 
 func (a *App) Render() {
+	select {
+	case <-a.closed:
+		return
+	default:
+	}
+
+	hooks, err := a.render()
+	if err != nil {
+		a.scopeLock.Lock()
+		var onError OnRenderError
+		a.scope.Assign(&onError)
+		a.scopeLock.Unlock()
+		// not holding the lock, handler may call Update
+		onError(err)
+		return
+	}
+
+	// mount and update hooks
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+func (a *App) render() (hooks []func(), err error) {
+	defer recoverErr(&err)
+	defer he(&err)
+
 	t0 := time.Now()
 	var slowThreshold SlowRenderThreshold
 	defer func() {
@@ -101,18 +244,125 @@ func (a *App) Render() {
 	a.scopeLock.Lock()
 	defer a.scopeLock.Unlock()
 
+	a.scope.Assign(&slowThreshold)
+
+	// definitions depending on Resources are resolved again in every render, to mark the used resources
+	a.resourceGen++
+	resources := Resources{
+		app: a,
+		gen: a.resourceGen,
+	}
+	a.scope = a.scope.Fork(func() Resources {
+		return resources
+	})
+
 	var rootElement RootElement
-	a.scope.Assign(&slowThreshold, &rootElement)
-	newNode := rootElement.(*Node)
-	var err error
-	a.element, err = patch(a.scope, newNode, a.element, a.rootNode)
-	ce(err)
+	a.scope.Assign(&rootElement)
+	newNode := rootNode(rootElement)
+	a.pendingHooks = nil
+	var element DOMNode
+	if a.hydrating {
+		a.hydrating = false
+		var mismatches []HydrationMismatch
+		element, mismatches, err = hydrate(a.scope, newNode, a.element, a.wrapElement)
+		if len(mismatches) > 0 {
+			var report OnHydrationMismatch
+			a.scope.Assign(&report)
+			// reported with the hooks, not holding the lock, handler may call Update
+			a.pendingHooks = append([]func(){
+				func() {
+					for _, mismatch := range mismatches {
+						report(mismatch)
+					}
+				},
+			}, a.pendingHooks...)
+		}
+	} else if a.rootNode == nil {
+		element, err = a.replaceRoot(newNode)
+	} else {
+		element, err = patch(a.scope, newNode, a.element, a.rootNode)
+	}
+	hooks = a.pendingHooks
+	a.pendingHooks = nil
+	if err != nil {
+		// partially patched, do full replacement in next render
+		a.rootNode = nil
+		return nil, err
+	}
+	a.element = element
 	a.rootNode = newNode
+	a.sweepResources(resources.gen)
+	return hooks, nil
+}
+
+// replaceRoot removes all rendered elements and renders node.
+// used in the first render, or after failed renders, which may leave partially patched elements
+func (a *App) replaceRoot(node *Node) (_ DOMNode, err error) {
+	defer he(&err)
+	for n := a.wrapElement.NumChildNodes(); n > 0; n-- {
+		a.removeElement(a.wrapElement.ChildNode(n - 1))
+	}
+	element, err := node.ToElement(a.scope)
+	ce(err)
+	first := node.firstElement(element)
+	a.wrapElement.AppendChild(element)
+	return first, nil
+}
+
+// Close stops rendering, removes event listeners and handlers, and removes the rendered elements
+func (a *App) Close() {
+	a.closeOnce.Do(func() {
+		close(a.closed)
+		a.stopHistory()
+
+		a.scopeLock.Lock()
+		defer a.scopeLock.Unlock()
+
+		if a.rootNode != nil {
+			for _, element := range siblingElements(a.element, a.rootNode.span()) {
+				a.releaseElement(element)
+			}
+		}
+
+		for key, remove := range a.listeners {
+			remove()
+			delete(a.listeners, key)
+		}
+		a.eventsLock.Lock()
+		a.eventRegistry = make(map[int32]map[string][]EventSpec)
+		a.onceCalled = make(map[int32]map[specKey]bool)
+		a.globalRegistry = make(map[int32]globalHandlers)
+		a.shortcuts = make(map[int32]shortcutEntry)
+		for _, limiters := range a.limiters {
+			for _, l := range limiters {
+				l.release()
+			}
+		}
+		a.limiters = make(map[int32]map[specKey]*limiter)
+		a.unmountHooks = make(map[int32][]any)
+		resources := a.resources
+		a.resources = make(map[resourceKey]*resourceEntry)
+		a.eventsLock.Unlock()
+		for _, entry := range resources {
+			entry.stop()
+		}
+
+		a.wrapElement.Remove()
+	})
 }
 
 func (a *App) HTML() string {
-	if a.element.InstanceOf(htmlElement) {
-		return a.element.Get("outerHTML").String()
+	span := 1
+	if a.rootNode != nil {
+		span = a.rootNode.span()
+	}
+	var b strings.Builder
+	for _, element := range siblingElements(a.element, span) {
+		if element.IsElement() {
+			b.WriteString(element.OuterHTML())
+		} else {
+			b.WriteString(element.NodeValue())
+		}
 	}
-	return a.element.Get("nodeValue").String()
+	return b.String()
 }
@@ -1,17 +1,186 @@
 package domui
 
 import (
-	"syscall/js"
 	"testing"
+	"time"
 )
 
 func WithTestApp(t *testing.T, fn func(*App), defs ...any) {
-	document := js.Global().Get("document")
-	element := document.Call("createElement", "div")
-	document.Get("body").Call("appendChild", element)
-	app := NewApp(
+	dom := NewMemDOM()
+	element := dom.CreateElement("div")
+	dom.Body().AppendChild(element)
+	app := NewDOMApp(
+		dom,
 		element,
 		defs...,
 	)
+	defer app.Close()
 	fn(app)
 }
+
+func waitFor(t *testing.T, fn func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second * 5)
+	for !fn() {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRenderOnAnimationFrame(t *testing.T) {
+	renders := 0
+	WithTestApp(
+		t,
+		func(app *App) {
+			dom := app.dom.(*MemDOM)
+			if renders != 1 {
+				t.Fatal()
+			}
+			for i := 1; i <= 3; i++ {
+				app.Update(func() int {
+					return i
+				})
+			}
+			if app.HTML() != `<p>0</p>` {
+				t.Fatal("rendered before frame")
+			}
+			waitFor(t, dom.Frame)
+			// rendered in the loop goroutine, not in the frame callback
+			waitFor(t, func() bool {
+				app.scopeLock.Lock()
+				defer app.scopeLock.Unlock()
+				return app.HTML() == `<p>3</p>`
+			})
+			app.scopeLock.Lock()
+			defer app.scopeLock.Unlock()
+			if renders != 2 {
+				t.Fatalf("got %d", renders)
+			}
+		},
+		func() int {
+			return 0
+		},
+		func(i int) RootElement {
+			renders++
+			return P(Text("%d", i))
+		},
+	)
+}
+
+func TestAnimationFrameNotBlocking(t *testing.T) {
+	block := make(chan struct{})
+	WithTestApp(
+		t,
+		func(app *App) {
+			dom := app.dom.(*MemDOM)
+			app.Update(func() int {
+				return 1
+			})
+			// the frame callback returns while the render is blocked
+			waitFor(t, dom.Frame)
+			close(block)
+			waitFor(t, func() bool {
+				app.scopeLock.Lock()
+				defer app.scopeLock.Unlock()
+				return app.HTML() == `<p>1</p>`
+			})
+		},
+		func() int {
+			return 0
+		},
+		func(i int) RootElement {
+			if i > 0 {
+				<-block
+			}
+			return P(Text("%d", i))
+		},
+	)
+}
+
+func TestRenderImmediately(t *testing.T) {
+	WithTestApp(
+		t,
+		func(app *App) {
+			app.Update(func() int {
+				return 1
+			})
+			waitFor(t, func() bool {
+				app.scopeLock.Lock()
+				defer app.scopeLock.Unlock()
+				return app.HTML() == `<p>1</p>`
+			})
+		},
+		func() RenderMode {
+			return RenderImmediately
+		},
+		func() int {
+			return 0
+		},
+		func(i int) RootElement {
+			return P(Text("%d", i))
+		},
+	)
+}
+
+func TestAppClose(t *testing.T) {
+	dom := NewMemDOM()
+	parent := dom.CreateElement("div")
+	dom.Body().AppendChild(parent)
+	clicks := 0
+	app := NewDOMApp(
+		dom,
+		parent,
+		func() RootElement {
+			return Div(
+				OnClick(func() {
+					clicks++
+				}),
+				P(
+					On("mouseover")(func() {}),
+				),
+			)
+		},
+	)
+	wrap := app.wrapElement.(*MemNode)
+	element := app.element.(*MemNode)
+	if len(wrap.listeners) != 2 {
+		t.Fatal()
+	}
+	element.Click()
+	if clicks != 1 {
+		t.Fatal()
+	}
+
+	app.Close()
+	app.Close()
+	if parent.NumChildNodes() != 0 {
+		t.Fatal("elements not removed")
+	}
+	if len(wrap.listeners) != 0 {
+		t.Fatal("listeners not removed")
+	}
+	app.eventsLock.RLock()
+	if len(app.eventRegistry) != 0 {
+		t.Fatal("handlers not cleared")
+	}
+	app.eventsLock.RUnlock()
+	if len(app.listeners) != 0 {
+		t.Fatal()
+	}
+	element.Click()
+	if clicks != 1 {
+		t.Fatal()
+	}
+
+	rootNode := app.rootNode
+	app.Update(func() RootElement {
+		return P()
+	})
+	app.Render()
+	dom.Frame()
+	if app.rootNode != rootNode {
+		t.Fatal("rendering after close")
+	}
+}
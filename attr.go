@@ -1,5 +1,7 @@
 package domui
 
+import "strings"
+
 type AttrsSpec struct {
 	Attrs map[string]any
 }
@@ -33,3 +35,85 @@ func Attr(name string) func(value any) AttrSpec {
 		}
 	}
 }
+
+// booleanAttrs are the html attributes whose presence means true
+var booleanAttrs = map[string]bool{
+	"allowfullscreen": true,
+	"async":           true,
+	"autofocus":       true,
+	"autoplay":        true,
+	"checked":         true,
+	"controls":        true,
+	"default":         true,
+	"defer":           true,
+	"disabled":        true,
+	"formnovalidate":  true,
+	"hidden":          true,
+	"inert":           true,
+	"ismap":           true,
+	"itemscope":       true,
+	"loop":            true,
+	"multiple":        true,
+	"muted":           true,
+	"nomodule":        true,
+	"novalidate":      true,
+	"open":            true,
+	"playsinline":     true,
+	"readonly":        true,
+	"required":        true,
+	"reversed":        true,
+	"selected":        true,
+}
+
+// attrValue returns the serialized attribute value, or false if the attribute should be absent.
+// nil removes the attribute. For boolean attributes like disabled, true sets an empty attribute and false removes it,
+// for others like aria-hidden or draggable, bools are serialized as "true" or "false"
+func attrValue(name string, value any) (string, bool) {
+	switch value := value.(type) {
+	case nil:
+		return "", false
+	case bool:
+		if booleanAttrs[strings.ToLower(name)] {
+			return "", value
+		}
+	}
+	return jsString(value), true
+}
+
+func setAttr(element DOMNode, name string, value any) {
+	v, ok := attrValue(name, value)
+	if !ok {
+		removeAttr(element, name)
+		return
+	}
+	if ns, _ := attrNamespace(name); ns != "" {
+		element.SetAttributeNS(ns, name, v)
+	} else {
+		element.SetAttribute(name, v)
+	}
+}
+
+func removeAttr(element DOMNode, name string) {
+	if ns, local := attrNamespace(name); ns != "" {
+		element.RemoveAttributeNS(ns, local)
+	} else {
+		element.RemoveAttribute(name)
+	}
+}
+
+type PropSpec struct {
+	Name  string
+	Value any
+}
+
+func (_ PropSpec) IsSpec() {}
+
+// Prop sets a DOM property, like value, checked or any non-string javascript value
+func Prop(name string) func(value any) PropSpec {
+	return func(value any) PropSpec {
+		return PropSpec{
+			Name:  name,
+			Value: value,
+		}
+	}
+}
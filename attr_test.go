@@ -0,0 +1,124 @@
+package domui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAttrAndProp(t *testing.T) {
+	WithTestApp(
+		t,
+		func(app *App) {
+			button := app.element.ChildNode(0)
+			input := app.element.ChildNode(1)
+			if html := app.HTML(); html != `<div><button disabled=""></button><input></div>` {
+				t.Fatalf("got %s", html)
+			}
+			if _, ok := button.GetProperty("disabled").(bool); !ok {
+				t.Fatal()
+			}
+			if v := input.GetProperty("value"); v != "foo" {
+				t.Fatalf("got %v", v)
+			}
+			if v := input.GetProperty("data"); v != 42 {
+				t.Fatalf("got %v", v)
+			}
+
+			// false removes boolean attributes, removed props are reset
+			app.Update(func() bool {
+				return false
+			})
+			app.Render()
+			if html := app.HTML(); html != `<div><button></button><input></div>` {
+				t.Fatalf("got %s", html)
+			}
+			if v := input.GetProperty("value"); v != "" {
+				t.Fatalf("got %#v", v)
+			}
+			if v := input.GetProperty("data"); v != nil {
+				t.Fatalf("got %v", v)
+			}
+
+			// true adds back
+			app.Update(func() bool {
+				return true
+			})
+			app.Render()
+			if html := app.HTML(); html != `<div><button disabled=""></button><input></div>` {
+				t.Fatalf("got %s", html)
+			}
+		},
+		func() bool {
+			return true
+		},
+		func(on bool) RootElement {
+			input := Tag("input")()
+			if on {
+				input = Tag("input")(
+					Prop("value")("foo"),
+					Prop("data")(42),
+				)
+			}
+			return Div(
+				Tag("button")(
+					Attr("disabled")(on),
+				),
+				input,
+			)
+		},
+	)
+}
+
+func TestBooleanAttrHTML(t *testing.T) {
+	html := Div(
+		Attr("hidden")(true),
+		Attr("disabled")(false),
+		Attr("title")("foo"),
+		Attr("aria-hidden")(false),
+		Attr("draggable")(true),
+		Prop("value")("bar"),
+	).HTML()
+	if html != `<div aria-hidden="false" draggable="true" hidden="" title="foo"></div>` {
+		t.Fatalf("got %s", html)
+	}
+}
+
+func TestNonBooleanAttrs(t *testing.T) {
+	WithTestApp(
+		t,
+		func(app *App) {
+			if html := app.HTML(); html != `<div aria-expanded="true" draggable="false"></div>` {
+				t.Fatalf("got %s", html)
+			}
+			if v := app.element.GetProperty("items"); !slices.Equal(v.([]int), []int{1}) {
+				t.Fatalf("got %v", v)
+			}
+
+			app.Update(func() bool {
+				return false
+			})
+			app.Render()
+			if html := app.HTML(); html != `<div aria-expanded="false" draggable="true"></div>` {
+				t.Fatalf("got %s", html)
+			}
+			// uncomparable values are always set
+			if v := app.element.GetProperty("items"); !slices.Equal(v.([]int), []int{1, 2}) {
+				t.Fatalf("got %v", v)
+			}
+		},
+		func() bool {
+			return true
+		},
+		func(on bool) RootElement {
+			items := []int{1}
+			if !on {
+				items = append(items, 2)
+			}
+			return Div(
+				Attr("aria-expanded")(on),
+				Attr("draggable")(!on),
+				Prop("items")(items),
+			)
+		},
+	)
+}
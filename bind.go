@@ -0,0 +1,235 @@
+package domui
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type BindSpec struct {
+	Ptr    any
+	Update Update
+}
+
+func (_ BindSpec) IsSpec() {}
+
+// Bind binds the value of an input, textarea, select or contenteditable element to the state value pointed by ptr.
+// On user input, the new value is stored to *ptr and passed to update.
+//
+// Checkboxes bind to bool, radios bind to the value of the checked one,
+// multiple selects bind to string slices, others bind to strings or numbers
+func Bind(ptr any, update Update) BindSpec {
+	if v := reflect.ValueOf(ptr); v.Kind() != reflect.Pointer || v.IsNil() {
+		panic(fmt.Errorf("bad bind pointer: %#v", ptr))
+	}
+	return BindSpec{
+		Ptr:    ptr,
+		Update: update,
+	}
+}
+
+type bindKind uint8
+
+const (
+	bindValue bindKind = iota
+	bindChecked
+	bindMultiple
+	bindContent
+)
+
+func bindKindOf(element DOMNode) (bindKind, error) {
+	switch strings.ToUpper(nodeName(element)) {
+	case "INPUT":
+		typ, _ := element.GetAttribute("type")
+		switch strings.ToLower(typ) {
+		case "checkbox", "radio":
+			return bindChecked, nil
+		}
+		return bindValue, nil
+	case "SELECT":
+		if multiple, _ := element.GetProperty("multiple").(bool); multiple {
+			return bindMultiple, nil
+		}
+		return bindValue, nil
+	case "TEXTAREA":
+		return bindValue, nil
+	}
+	// isContentEditable is false for detached elements
+	switch element.GetProperty("contentEditable") {
+	case "true", "plaintext-only":
+		return bindContent, nil
+	}
+	return 0, fmt.Errorf("bad bind element: %s", nodeName(element))
+}
+
+// onInput stores the element value to the bound state
+func (b BindSpec) onInput(element DOMNode) {
+	target := reflect.ValueOf(b.Ptr).Elem()
+	kind, err := bindKindOf(element)
+	if err != nil {
+		return
+	}
+
+	switch kind {
+
+	case bindChecked:
+		checked, _ := element.GetProperty("checked").(bool)
+		if target.Kind() == reflect.Bool {
+			target.SetBool(checked)
+		} else if !checked {
+			// unchecked radio
+			return
+		} else if err := setFromString(target, propString(element, "value")); err != nil {
+			return
+		}
+
+	case bindMultiple:
+		values := selectedValues(element)
+		slice := reflect.MakeSlice(target.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setFromString(slice.Index(i), value); err != nil {
+				return
+			}
+		}
+		target.Set(slice)
+
+	case bindContent:
+		if err := setFromString(target, propString(element, "textContent")); err != nil {
+			return
+		}
+
+	default:
+		if err := setFromString(target, propString(element, "value")); err != nil {
+			// incomplete input, like "-" in number inputs
+			return
+		}
+
+	}
+
+	b.Update(b.Ptr)
+}
+
+// apply sets the element value to the bound state
+func (b BindSpec) apply(element DOMNode) error {
+	target := reflect.ValueOf(b.Ptr).Elem()
+	kind, err := bindKindOf(element)
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+
+	case bindChecked:
+		var checked bool
+		if target.Kind() == reflect.Bool {
+			checked = target.Bool()
+		} else {
+			value, _ := element.GetAttribute("value")
+			checked = value == sp("%v", target.Interface())
+		}
+		if current, _ := element.GetProperty("checked").(bool); current != checked {
+			element.SetProperty("checked", checked)
+		}
+
+	case bindMultiple:
+		var values []string
+		for i := 0; i < target.Len(); i++ {
+			values = append(values, sp("%v", target.Index(i).Interface()))
+		}
+		walkOptions(element, func(option DOMNode) {
+			selected := slices.Contains(values, propString(option, "value"))
+			if current, _ := option.GetProperty("selected").(bool); current != selected {
+				option.SetProperty("selected", selected)
+			}
+		})
+
+	case bindContent:
+		value := sp("%v", target.Interface())
+		if propString(element, "textContent") != value {
+			element.SetProperty("textContent", value)
+		}
+
+	default:
+		value := sp("%v", target.Interface())
+		if propString(element, "value") == value {
+			// not touching the value preserves cursor position
+			return nil
+		}
+		start := element.GetProperty("selectionStart")
+		end := element.GetProperty("selectionEnd")
+		element.SetProperty("value", value)
+		if start != nil && end != nil {
+			element.SetProperty("selectionStart", start)
+			element.SetProperty("selectionEnd", end)
+		}
+
+	}
+	return nil
+}
+
+func propString(element DOMNode, name string) string {
+	switch v := element.GetProperty(name).(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return jsString(v)
+	}
+}
+
+func walkOptions(element DOMNode, fn func(DOMNode)) {
+	for i := 0; i < element.NumChildNodes(); i++ {
+		child := element.ChildNode(i)
+		if strings.EqualFold(nodeName(child), "option") {
+			fn(child)
+		} else {
+			walkOptions(child, fn)
+		}
+	}
+}
+
+func selectedValues(element DOMNode) (values []string) {
+	walkOptions(element, func(option DOMNode) {
+		if selected, _ := option.GetProperty("selected").(bool); selected {
+			values = append(values, propString(option, "value"))
+		}
+	})
+	return
+}
+
+func setFromString(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type: %v", v.Type())
+	}
+	return nil
+}
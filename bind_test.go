@@ -0,0 +1,225 @@
+package domui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBind(t *testing.T) {
+	type (
+		Name  string
+		Agree bool
+		Tags  []string
+		Age   int
+		Color string
+		Note  string
+	)
+
+	WithTestApp(
+		t,
+		func(app *App) {
+			child := func(i int) *MemNode {
+				return app.element.ChildNode(i).(*MemNode)
+			}
+			input := func(element *MemNode, prop string, value any) {
+				element.SetProperty(prop, value)
+				element.DispatchEvent(NewMemEvent("input", true))
+				app.Render()
+			}
+
+			// text
+			text := child(0)
+			if v := text.GetProperty("value"); v != "foo" {
+				t.Fatalf("got %v", v)
+			}
+			text.SetProperty("selectionStart", 2)
+			input(text, "value", "bar")
+			var name Name
+			app.scope.Assign(&name)
+			if name != "bar" {
+				t.Fatalf("got %v", name)
+			}
+			if v := text.GetProperty("selectionStart"); v != 2 {
+				t.Fatalf("got %v", v)
+			}
+			app.Update(func() Name {
+				return "baz"
+			})
+			app.Render()
+			if v := text.GetProperty("value"); v != "baz" {
+				t.Fatalf("got %v", v)
+			}
+
+			// checkbox
+			checkbox := child(1)
+			if v := checkbox.GetProperty("checked"); v != false {
+				t.Fatalf("got %v", v)
+			}
+			input(checkbox, "checked", true)
+			var agree Agree
+			app.scope.Assign(&agree)
+			if !agree {
+				t.Fatal()
+			}
+			if v := checkbox.GetProperty("checked"); v != true {
+				t.Fatalf("got %v", v)
+			}
+
+			// multiple select
+			sel := child(2)
+			option := func(i int) *MemNode {
+				return sel.ChildNode(i).(*MemNode)
+			}
+			if option(0).GetProperty("selected") != true ||
+				option(1).GetProperty("selected") != false ||
+				option(2).GetProperty("selected") != true {
+				t.Fatal()
+			}
+			option(0).SetProperty("selected", false)
+			input(option(1), "selected", true)
+			var tags Tags
+			app.scope.Assign(&tags)
+			if !slices.Equal(tags, Tags{"b", "c"}) {
+				t.Fatalf("got %v", tags)
+			}
+
+			// number
+			number := child(3)
+			input(number, "value", "42")
+			var age Age
+			app.scope.Assign(&age)
+			if age != 42 {
+				t.Fatalf("got %v", age)
+			}
+			input(number, "value", "-")
+			app.scope.Assign(&age)
+			if age != 42 {
+				t.Fatalf("got %v", age)
+			}
+
+			// radio
+			red, blue := child(4), child(5)
+			if red.GetProperty("checked") != true || blue.GetProperty("checked") != false {
+				t.Fatal()
+			}
+			red.SetProperty("checked", false)
+			input(blue, "checked", true)
+			var color Color
+			app.scope.Assign(&color)
+			if color != "blue" {
+				t.Fatalf("got %v", color)
+			}
+			if red.GetProperty("checked") != false || blue.GetProperty("checked") != true {
+				t.Fatal()
+			}
+
+			// contenteditable
+			editable := child(6)
+			if editable.TextContent() != "hello" {
+				t.Fatal()
+			}
+			input(editable, "textContent", "world")
+			var note Note
+			app.scope.Assign(&note)
+			if note != "world" {
+				t.Fatalf("got %v", note)
+			}
+		},
+		func() (Name, Agree, Tags, Age, Color, Note) {
+			return "foo", false, Tags{"a", "c"}, 0, "red", "hello"
+		},
+		func(
+			name Name,
+			agree Agree,
+			tags Tags,
+			age Age,
+			color Color,
+			note Note,
+			update Update,
+		) RootElement {
+			Input := Tag("input")
+			Type := Attr("type")
+			Value := Attr("value")
+			Option := Tag("option")
+			return Div(
+				Input(Bind(&name, update)),
+				Input(Type("checkbox"), Bind(&agree, update)),
+				Tag("select")(
+					Attr("multiple")(true),
+					Option(Value("a")),
+					Option(Value("b")),
+					Option(Value("c")),
+					Bind(&tags, update),
+				),
+				Input(Type("number"), Bind(&age, update)),
+				Input(Type("radio"), Value("red"), Bind(&color, update)),
+				Input(Type("radio"), Value("blue"), Bind(&color, update)),
+				Div(Attr("contenteditable")("true"), Bind(&note, update)),
+			)
+		},
+	)
+}
+
+func TestBindElements(t *testing.T) {
+	type (
+		Tags  []string
+		Plain bool
+	)
+	var errs []error
+	WithTestApp(
+		t,
+		func(app *App) {
+			// multiple property
+			sel := app.element.ChildNode(0).(*MemNode)
+			sel.ChildNode(1).SetProperty("selected", true)
+			sel.DispatchEvent(NewMemEvent("input", true))
+			app.Render()
+			var tags Tags
+			app.scope.Assign(&tags)
+			if !slices.Equal(tags, Tags{"a", "b"}) {
+				t.Fatalf("got %v", tags)
+			}
+
+			// not bindable
+			if len(errs) != 0 {
+				t.Fatal()
+			}
+			app.Update(func() Plain {
+				return true
+			})
+			app.Render()
+			if len(errs) != 1 {
+				t.Fatal()
+			}
+			if !strings.Contains(errs[0].Error(), "bad bind element: DIV") {
+				t.Fatalf("got %v", errs[0])
+			}
+		},
+		func() (Tags, Plain) {
+			return Tags{"a"}, false
+		},
+		func() OnRenderError {
+			return func(err error) {
+				errs = append(errs, err)
+			}
+		},
+		func(tags Tags, plain Plain, update Update) RootElement {
+			Option := Tag("option")
+			Value := Attr("value")
+			var note string
+			return Div(
+				Tag("select")(
+					Prop("multiple")(true),
+					Option(Value("a")),
+					Option(Value("b")),
+					Bind(&tags, update),
+				),
+				If(bool(plain), Div(
+					Text("child"),
+					Bind(&note, update),
+				)),
+			)
+		},
+	)
+}
@@ -0,0 +1,100 @@
+package domui
+
+// DOM is the document backend that nodes are rendered into.
+// The browser backend is JSDOM; other backends allow rendering and testing without syscall/js.
+type DOM interface {
+	CreateElement(tag string) DOMNode
+	CreateElementNS(namespace string, tag string) DOMNode
+	CreateTextNode(data string) DOMNode
+	CreateDocumentFragment() DOMNode
+	// ActiveElement returns the focused element, or nil
+	ActiveElement() DOMNode
+	// RequestAnimationFrame calls fn before the next repaint
+	RequestAnimationFrame(fn func())
+	Window() EventTarget
+	Document() EventTarget
+	// QuerySelector returns the first element of the document matching selector, or nil
+	QuerySelector(selector string) DOMNode
+}
+
+// EventTarget is an object that can be listened to, like DOMNode, window and document
+type EventTarget interface {
+	AddEventListener(event string, fn func(DOMEvent), options ListenerOptions) (remove func())
+}
+
+// DOMNode is an element, text node or document fragment of a DOM.
+type DOMNode interface {
+	Equal(node DOMNode) bool
+	IsElement() bool
+
+	// tree
+	ParentNode() DOMNode
+	// NextSibling returns the node following this in the parent, or nil
+	NextSibling() DOMNode
+	ChildNode(i int) DOMNode
+	NumChildNodes() int
+	AppendChild(child DOMNode)
+	InsertBefore(child DOMNode, ref DOMNode)
+	Remove()
+
+	// content
+	NodeValue() string
+	SetData(data string)
+	OuterHTML() string
+
+	// attributes and properties
+	GetAttribute(name string) (string, bool)
+	AttributeNames() []string
+	SetAttribute(name string, value any)
+	RemoveAttribute(name string)
+	SetAttributeNS(namespace string, name string, value any)
+	RemoveAttributeNS(namespace string, localName string)
+	GetProperty(name string) any
+	SetProperty(name string, value any)
+	DeleteProperty(name string)
+
+	// classes and styles
+	AddClass(name string)
+	RemoveClass(name string)
+	SetStyleText(text string)
+	SetStyle(name string, value string)
+	RemoveStyle(name string)
+
+	// events
+	EventTarget
+
+	// layout
+	Focus()
+	HasScrollBar() bool
+	Reflow()
+}
+
+// DOMEvent is an event dispatched by a DOM.
+type DOMEvent interface {
+	Type() string
+	Bubbles() bool
+	Target() DOMNode
+	// Get returns the property of the event as Go value
+	Get(name string) any
+	PreventDefault()
+	StopPropagation()
+	// StopImmediatePropagation also stops other listeners of the current element
+	StopImmediatePropagation()
+}
+
+// nodeDefs is implemented by DOMNodes that provide extra definitions to event handlers
+type nodeDefs interface {
+	defs() []any
+}
+
+func elementIDOf(node DOMNode) (int32, bool) {
+	switch v := node.GetProperty("__element_id__").(type) {
+	case int32:
+		return v, true
+	case int:
+		return int32(v), true
+	case float64:
+		return int32(v), true
+	}
+	return 0, false
+}
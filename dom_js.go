@@ -0,0 +1,307 @@
+package domui
+
+import (
+	"syscall/js"
+)
+
+// JSDOM is the DOM backend of the browser document
+var JSDOM DOM = jsDOM{}
+
+type jsDOM struct{}
+
+func (_ jsDOM) CreateElement(tag string) DOMNode {
+	return jsNode{document.Call("createElement", tag)}
+}
+
+func (_ jsDOM) CreateElementNS(namespace string, tag string) DOMNode {
+	return jsNode{document.Call("createElementNS", namespace, tag)}
+}
+
+func (_ jsDOM) CreateTextNode(data string) DOMNode {
+	return jsNode{document.Call("createTextNode", data)}
+}
+
+func (_ jsDOM) CreateDocumentFragment() DOMNode {
+	return jsNode{document.Call("createDocumentFragment")}
+}
+
+func (_ jsDOM) ActiveElement() DOMNode {
+	return JSNode(document.Get("activeElement"))
+}
+
+// callbacks must not block the javascript event loop
+func (_ jsDOM) async(fn func()) {
+	go fn()
+}
+
+func (_ jsDOM) Window() EventTarget {
+	return jsNode{global}
+}
+
+func (_ jsDOM) Document() EventTarget {
+	return jsNode{document}
+}
+
+func (_ jsDOM) QuerySelector(selector string) DOMNode {
+	return JSNode(document.Call("querySelector", selector))
+}
+
+func (_ jsDOM) RequestAnimationFrame(fn func()) {
+	var f js.Func
+	f = js.FuncOf(func(this js.Value, args []js.Value) any {
+		f.Release()
+		fn()
+		return nil
+	})
+	global.Call("requestAnimationFrame", f)
+}
+
+type jsNode struct {
+	value js.Value
+}
+
+// JSNode wraps a js.Value as DOMNode. null and undefined are wrapped as nil
+func JSNode(value js.Value) DOMNode {
+	if value.IsNull() || value.IsUndefined() {
+		return nil
+	}
+	return jsNode{value}
+}
+
+// JSValue returns the js.Value of a DOMNode created by JSDOM
+func JSValue(node DOMNode) js.Value {
+	if n, ok := node.(jsNode); ok {
+		return n.value
+	}
+	return js.Undefined()
+}
+
+func (n jsNode) defs() []any {
+	return []any{
+		func() js.Value {
+			return n.value
+		},
+	}
+}
+
+func (n jsNode) Equal(node DOMNode) bool {
+	other, ok := node.(jsNode)
+	return ok && n.value.Equal(other.value)
+}
+
+func (n jsNode) IsElement() bool {
+	return n.value.InstanceOf(elementClass)
+}
+
+func (n jsNode) ParentNode() DOMNode {
+	return JSNode(n.value.Get("parentNode"))
+}
+
+func (n jsNode) NextSibling() DOMNode {
+	return JSNode(n.value.Get("nextSibling"))
+}
+
+func (n jsNode) ChildNode(i int) DOMNode {
+	return JSNode(n.value.Get("childNodes").Index(i))
+}
+
+func (n jsNode) NumChildNodes() int {
+	return n.value.Get("childNodes").Length()
+}
+
+func (n jsNode) AppendChild(child DOMNode) {
+	n.value.Call("appendChild", JSValue(child))
+}
+
+func (n jsNode) InsertBefore(child DOMNode, ref DOMNode) {
+	if ref == nil {
+		n.value.Call("insertBefore", JSValue(child), nil)
+		return
+	}
+	n.value.Call("insertBefore", JSValue(child), JSValue(ref))
+}
+
+func (n jsNode) Remove() {
+	n.value.Call("remove")
+}
+
+func (n jsNode) NodeValue() string {
+	return n.value.Get("nodeValue").String()
+}
+
+func (n jsNode) SetData(data string) {
+	n.value.Set("data", data)
+}
+
+func (n jsNode) OuterHTML() string {
+	return n.value.Get("outerHTML").String()
+}
+
+func (n jsNode) GetAttribute(name string) (string, bool) {
+	value := n.value.Call("getAttribute", name)
+	if value.IsNull() {
+		return "", false
+	}
+	return value.String(), true
+}
+
+func (n jsNode) AttributeNames() []string {
+	value := n.value.Call("getAttributeNames")
+	names := make([]string, value.Length())
+	for i := range names {
+		names[i] = value.Index(i).String()
+	}
+	return names
+}
+
+func (n jsNode) SetAttribute(name string, value any) {
+	n.value.Call("setAttribute", name, value)
+}
+
+func (n jsNode) RemoveAttribute(name string) {
+	n.value.Call("removeAttribute", name)
+}
+
+func (n jsNode) SetAttributeNS(namespace string, name string, value any) {
+	n.value.Call("setAttributeNS", namespace, name, value)
+}
+
+func (n jsNode) RemoveAttributeNS(namespace string, localName string) {
+	n.value.Call("removeAttributeNS", namespace, localName)
+}
+
+func (n jsNode) GetProperty(name string) any {
+	return fromJS(n.value.Get(name))
+}
+
+func (n jsNode) SetProperty(name string, value any) {
+	n.value.Set(name, value)
+}
+
+func (n jsNode) DeleteProperty(name string) {
+	n.value.Delete(name)
+}
+
+func (n jsNode) AddClass(name string) {
+	n.value.Get("classList").Call("add", name)
+}
+
+func (n jsNode) RemoveClass(name string) {
+	n.value.Get("classList").Call("remove", name)
+}
+
+func (n jsNode) SetStyleText(text string) {
+	n.value.Set("style", text)
+}
+
+func (n jsNode) SetStyle(name string, value string) {
+	n.value.Get("style").Set(name, value)
+}
+
+func (n jsNode) RemoveStyle(name string) {
+	n.value.Get("style").Set(name, nil)
+}
+
+func (n jsNode) AddEventListener(event string, fn func(DOMEvent), options ListenerOptions) (remove func()) {
+	f := js.FuncOf(func(this js.Value, args []js.Value) any {
+		// fn is called synchronously, so it can cancel the event. it must not block
+		fn(jsEvent{args[0]})
+		return nil
+	})
+	opts := map[string]any{
+		"capture": options.Capture,
+		"passive": options.Passive,
+	}
+	n.value.Call("addEventListener", event, f, opts)
+	return func() {
+		n.value.Call("removeEventListener", event, f, opts)
+		f.Release()
+	}
+}
+
+func (n jsNode) Focus() {
+	n.value.Call("focus")
+}
+
+func (n jsNode) HasScrollBar() bool {
+	if !n.value.InstanceOf(htmlElement) {
+		return false
+	}
+	return n.value.Get("scrollWidth").Int() > n.value.Get("clientWidth").Int() ||
+		n.value.Get("scrollHeight").Int() > n.value.Get("clientHeight").Int()
+}
+
+func (n jsNode) Reflow() {
+	n.value.Get("offsetHeight")
+}
+
+// Value returns the js.Value of the referenced element, or undefined
+func (r *Ref) Value() js.Value {
+	return JSValue(r.Element())
+}
+
+type jsEvent struct {
+	value js.Value
+}
+
+func (e jsEvent) Type() string {
+	return e.value.Get("type").String()
+}
+
+func (e jsEvent) Bubbles() bool {
+	return e.value.Get("bubbles").Bool()
+}
+
+func (e jsEvent) Target() DOMNode {
+	return JSNode(e.value.Get("target"))
+}
+
+func (e jsEvent) Get(name string) any {
+	return fromJS(e.value.Get(name))
+}
+
+func (e jsEvent) PreventDefault() {
+	e.value.Call("preventDefault")
+}
+
+func (e jsEvent) StopPropagation() {
+	e.value.Call("stopPropagation")
+}
+
+func (e jsEvent) StopImmediatePropagation() {
+	e.value.Call("stopImmediatePropagation")
+}
+
+// Value returns the js.Value of the event dispatched by JSDOM, or undefined
+func (e Event) Value() js.Value {
+	ev := e.DOMEvent
+	if dispatched, ok := ev.(*dispatchedEvent); ok {
+		ev = dispatched.DOMEvent
+	}
+	if ev, ok := ev.(jsEvent); ok {
+		return ev.value
+	}
+	return js.Undefined()
+}
+
+func fromJS(value js.Value) any {
+	switch value.Type() {
+	case js.TypeUndefined, js.TypeNull:
+		return nil
+	case js.TypeBoolean:
+		return value.Bool()
+	case js.TypeNumber:
+		return value.Float()
+	case js.TypeString:
+		return value.String()
+	}
+	return value
+}
+
+// NewApp creates an App rendering to renderElement of the browser document
+func NewApp(
+	renderElement js.Value,
+	defs ...any,
+) *App {
+	return NewDOMApp(JSDOM, JSNode(renderElement), defs...)
+}
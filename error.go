@@ -0,0 +1,85 @@
+package domui
+
+import (
+	"errors"
+	"fmt"
+)
+
+// OnRenderError handles errors in resolving declarations, applying specs and patching
+type OnRenderError func(error)
+
+func (_ Def) OnRenderError() OnRenderError {
+	return func(err error) {
+		logErr("render error: %v", err)
+	}
+}
+
+// recoverErr converts panics to errors. Must be deferred directly
+func recoverErr(errp *error) {
+	p := recover()
+	if p == nil {
+		return
+	}
+	if err, ok := p.(error); ok {
+		*errp = err
+	} else {
+		*errp = fmt.Errorf("%v", p)
+	}
+}
+
+type ErrorBoundarySpec struct {
+	Fallback func(error) Spec
+	Child    Spec
+}
+
+func (_ ErrorBoundarySpec) IsSpec() {}
+
+// ErrorBoundary applies child, or the fallback if child panics while being applied.
+// Use a Lazy child to also catch panics in constructing it
+func ErrorBoundary(fallback func(error) Spec, child Spec) ErrorBoundarySpec {
+	return ErrorBoundarySpec{
+		Fallback: fallback,
+		Child:    child,
+	}
+}
+
+func (node *Node) applyBoundary(spec ErrorBoundarySpec) {
+	// apply to a copy, so a failed child leaves no partial state
+	n := node.clone()
+	if err := n.tryApplySpec(spec.Child); err != nil {
+		if errors.Is(err, ErrResourceLoading) {
+			// handled by Suspense
+			panic(err)
+		}
+		node.ApplySpec(spec.Fallback(err))
+		return
+	}
+	*node = *n
+}
+
+func (node *Node) tryApplySpec(spec Spec) (err error) {
+	defer recoverErr(&err)
+	defer he(&err)
+	node.ApplySpec(spec)
+	return nil
+}
+
+func (node *Node) clone() *Node {
+	n := *node
+	n.Styles = append(node.Styles[:0:0], node.Styles...)
+	n.Classes = append(node.Classes[:0:0], node.Classes...)
+	n.Attributes = append(node.Attributes[:0:0], node.Attributes...)
+	n.Props = append(node.Props[:0:0], node.Props...)
+	if node.Events != nil {
+		n.Events = make(map[string][]EventSpec, len(node.Events))
+		for k, v := range node.Events {
+			n.Events[k] = append(v[:0:0], v...)
+		}
+	}
+	n.GlobalEvents = append(node.GlobalEvents[:0:0], node.GlobalEvents...)
+	n.Shortcuts = append(node.Shortcuts[:0:0], node.Shortcuts...)
+	n.Hooks = append(node.Hooks[:0:0], node.Hooks...)
+	n.Refs = append(node.Refs[:0:0], node.Refs...)
+	n.childNodes = append(node.childNodes[:0:0], node.childNodes...)
+	return &n
+}
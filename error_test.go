@@ -0,0 +1,118 @@
+package domui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type badSpec struct{}
+
+func (_ badSpec) IsSpec() {}
+
+func TestRenderError(t *testing.T) {
+	var errs []error
+	WithTestApp(
+		t,
+		func(app *App) {
+			if len(errs) != 0 {
+				t.Fatal()
+			}
+
+			app.Update(func() int {
+				return 1
+			})
+			app.Render()
+			if len(errs) != 1 {
+				t.Fatal()
+			}
+			if !strings.Contains(errs[0].Error(), "unknown spec") {
+				t.Fatalf("got %v", errs[0])
+			}
+			if html := app.HTML(); html != `<p>0</p>` {
+				t.Fatalf("got %s", html)
+			}
+
+			app.Update(func() int {
+				return 2
+			})
+			app.Render()
+			if len(errs) != 2 {
+				t.Fatal()
+			}
+			if !errors.Is(errs[1], errBadDecl) {
+				t.Fatalf("got %v", errs[1])
+			}
+
+			app.Update(func() int {
+				return 3
+			})
+			app.Render()
+			if len(errs) != 2 {
+				t.Fatal()
+			}
+			if html := app.HTML(); html != `<p>3</p>` {
+				t.Fatalf("got %s", html)
+			}
+		},
+		func() OnRenderError {
+			return func(err error) {
+				errs = append(errs, err)
+			}
+		},
+		func() int {
+			return 0
+		},
+		func(i int) RootElement {
+			switch i {
+			case 1:
+				return P(badSpec{})
+			case 2:
+				panic(errBadDecl)
+			}
+			return P(Text("%d", i))
+		},
+	)
+}
+
+var errBadDecl = errors.New("bad decl")
+
+func TestErrorBoundary(t *testing.T) {
+	fallback := func(err error) Spec {
+		return Specs{
+			Class("error"),
+			Text("%v", err),
+		}
+	}
+
+	node := Div(
+		ID("foo"),
+		ErrorBoundary(fallback, Lazy(func() Spec {
+			panic(errBadDecl)
+		})),
+	)
+	if html := node.HTML(); html != `<div id="foo" class="error">bad decl</div>` {
+		t.Fatalf("got %s", html)
+	}
+
+	node = Div(
+		ErrorBoundary(fallback, Specs{
+			Class("ok"),
+			P(),
+			badSpec{},
+		}),
+	)
+	if html := node.HTML(); !strings.HasPrefix(html, `<div class="error">unknown spec`) {
+		t.Fatalf("got %s", html)
+	}
+
+	node = Div(
+		ErrorBoundary(fallback, Specs{
+			Class("ok"),
+			P(),
+		}),
+	)
+	if html := node.HTML(); html != `<div class="ok"><p></p></div>` {
+		t.Fatalf("got %s", html)
+	}
+}
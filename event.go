@@ -1,119 +1,519 @@
 package domui
 
 import (
-	"sync"
-	"sync/atomic"
-	"syscall/js"
+	"reflect"
+	"slices"
 
 	"github.com/reusee/dscope"
 )
 
-var elementID int32 = 42
-
 type EventSpec struct {
-	Event string
-	Func  any
+	Event     string
+	Func      any
+	Modifiers EventModifier
+	Source    EventSource
+	// Filter decides whether the event is handled, before applying modifiers
+	Filter func(DOMEvent) bool
+	// RateLimit debounces or throttles the handler, see Debounce and Throttle
+	RateLimit *RateLimit
 }
 
 func (_ EventSpec) IsSpec() {}
 
-func On(ev string) func(cb any) EventSpec {
+// EventModifier changes how an event handler is called
+type EventModifier uint8
+
+const (
+	// call preventDefault before calling the handler
+	PreventDefault EventModifier = 1 << iota
+	// stop propagation after calling handlers of the element
+	StopPropagation
+	// call the handler at most once for the element, until a render replaces it with a different function
+	Once
+	// listen with a passive listener, preventDefault has no effect
+	Passive
+	// call the handler in the capturing phase, before handlers of descendants
+	Capture
+	// call the handler synchronously in the browser event callback, so it can call Event.PreventDefault.
+	// Sync handlers must not block, and must not call Update or Navigate, which wait for a running render.
+	// Events dispatched synchronously by the render, like blur of removed elements, would deadlock
+	Sync
+)
+
+// EventSource is the object that event handlers listen to
+type EventSource uint8
+
+const (
+	// events dispatched to the element, or its descendants if bubbling
+	ElementSource EventSource = iota
+	// events dispatched to the window, like resize and popstate
+	WindowSource
+	// events dispatched to the document, like visibilitychange
+	DocumentSource
+)
+
+func On(ev string, modifiers ...EventModifier) func(cb any) EventSpec {
 	return func(cb any) EventSpec {
 		return EventSpec{
 			Event: ev,
 			Func:  cb,
-		}
+		}.With(modifiers...)
 	}
 }
 
-var (
-	eventRegistryLock sync.RWMutex
-	eventRegistry     = make(map[int32]map[string][]EventSpec)
-	eventHandlerSet   = make(map[string]bool)
-)
+// OnWindow handles events of the window while the element is mounted
+func OnWindow(ev string, modifiers ...EventModifier) func(cb any) EventSpec {
+	return func(cb any) EventSpec {
+		spec := On(ev, modifiers...)(cb)
+		spec.Source = WindowSource
+		return spec
+	}
+}
+
+// OnDocument handles events of the document while the element is mounted
+func OnDocument(ev string, modifiers ...EventModifier) func(cb any) EventSpec {
+	return func(cb any) EventSpec {
+		spec := On(ev, modifiers...)(cb)
+		spec.Source = DocumentSource
+		return spec
+	}
+}
+
+// With returns the spec with modifiers added
+func (s EventSpec) With(modifiers ...EventModifier) EventSpec {
+	for _, m := range modifiers {
+		s.Modifiers |= m
+	}
+	return s
+}
+
+// ListenerOptions is the options of DOMNode.AddEventListener
+type ListenerOptions struct {
+	Capture bool
+	Passive bool
+}
+
+type listenerKey struct {
+	source  EventSource
+	event   string
+	passive bool
+	capture bool
+}
+
+// specKey identifies an event spec of an element across renders
+type specKey struct {
+	source EventSource
+	event  string
+	index  int
+}
+
+type globalHandlers struct {
+	element DOMNode
+	specs   []EventSpec
+}
+
+// asyncDOM is implemented by DOMs whose event callbacks must not block, like JSDOM
+type asyncDOM interface {
+	async(fn func())
+}
 
 var eventHandlerScope = dscope.New()
 
-func setEventSpecs(wrap js.Value, element js.Value, specs map[string][]EventSpec) {
+func (a *App) setEventSpecs(element DOMNode, specs map[string][]EventSpec) {
+	id := a.ensureElementID(element)
+
+	for event, ss := range specs {
+		for _, spec := range ss {
+			key := listenerKey{
+				event:   event,
+				passive: spec.Modifiers&Passive != 0,
+			}
+			if _, ok := a.listeners[key]; ok {
+				continue
+			}
+			a.listeners[key] = a.addRootListener(a.wrapElement, key)
+			for _, p := range a.portals {
+				p.listeners[key] = a.addRootListener(p.container, key)
+			}
+			if !key.passive {
+				// the passive listener is re-added after the non-passive one, so handlers stopping propagation run first
+				passiveKey := key
+				passiveKey.passive = true
+				if remove, ok := a.listeners[passiveKey]; ok {
+					remove()
+					a.listeners[passiveKey] = a.addRootListener(a.wrapElement, passiveKey)
+					for _, p := range a.portals {
+						if remove, ok := p.listeners[passiveKey]; ok {
+							remove()
+						}
+						p.listeners[passiveKey] = a.addRootListener(p.container, passiveKey)
+					}
+				}
+			}
+		}
+	}
+
+	a.eventsLock.Lock()
+	for event, lastSpecs := range a.eventRegistry[id] {
+		a.resetReplaced(id, lastSpecs, specs[event])
+	}
+	a.eventRegistry[id] = specs
+	a.eventsLock.Unlock()
+
+}
+
+// addRootListener adds the delegating listener of key to root, which is the wrap element or a portal container
+func (a *App) addRootListener(root DOMNode, key listenerKey) (remove func()) {
+	return root.AddEventListener(
+		key.event,
+		func(ev DOMEvent) {
+			if a.isNestedRoot(root) {
+				// dispatched by the outer root
+				return
+			}
+			a.dispatchEvent(ev, key.passive)
+		},
+		ListenerOptions{
+			// handlers are called in the capturing phase of the root element, before any listener of the elements
+			Capture: true,
+			Passive: key.passive,
+		},
+	)
+}
+
+// isNestedRoot reports whether root is in the wrap element or a portal container
+func (a *App) isNestedRoot(root DOMNode) bool {
+	if root.Equal(a.wrapElement) {
+		return false
+	}
+	a.eventsLock.RLock()
+	defer a.eventsLock.RUnlock()
+	for node := root.ParentNode(); node != nil; node = node.ParentNode() {
+		if node.Equal(a.wrapElement) {
+			return true
+		}
+		if id, ok := elementIDOf(node); ok && a.portalContainers[id] != nil {
+			return true
+		}
+	}
+	return false
+}
 
-	idValue := element.Get("__element_id__")
-	var id int32
-	if idValue.IsUndefined() {
-		id = atomic.AddInt32(&elementID, 1)
-		element.Set("__element_id__", id)
-	} else {
-		id = int32(idValue.Int())
+// dispatchEvent calls handlers of elements on the event path, emulating capturing and bubbling phases
+func (a *App) dispatchEvent(ev DOMEvent, passive bool) {
+	typ := ev.Type()
+	var path []DOMNode // from target to wrap
+	a.eventsLock.RLock()
+	for node := ev.Target(); node != nil && !node.Equal(a.wrapElement); node = node.ParentNode() {
+		path = append(path, node)
+		if id, ok := elementIDOf(node); ok {
+			if p := a.portalContainers[id]; p != nil {
+				// continue from the position of the portal
+				node = p.placeholder
+			}
+		}
 	}
+	a.eventsLock.RUnlock()
 
-	for event := range specs {
-		if eventHandlerSet[event] {
+	dispatched := &dispatchedEvent{
+		DOMEvent: ev,
+	}
+	var deferred []func()
+	invoke := func(node DOMNode, capture bool) {
+		id, ok := elementIDOf(node)
+		if !ok {
+			return
+		}
+		a.eventsLock.Lock()
+		specs := a.takeSpecs(ev, id, ElementSource, a.eventRegistry[id][typ], capture, passive)
+		a.eventsLock.Unlock()
+		a.callSpecs(node, dispatched, specs, &deferred)
+	}
+
+	// capturing
+	for i := len(path) - 1; i >= 0 && !dispatched.stopped; i-- {
+		invoke(path[i], true)
+	}
+	// target, and bubbling for bubbling events only
+	for i, node := range path {
+		if dispatched.stopped || (i > 0 && !ev.Bubbles()) {
+			break
+		}
+		invoke(node, false)
+	}
+
+	a.runDeferred(deferred)
+}
+
+// takenSpec is a spec to be called, with the rate limiter of the spec if any
+type takenSpec struct {
+	EventSpec
+	limiter *limiter
+}
+
+// callSpecs applies modifiers and calls handlers of specs.
+// handlers are appended to deferred if they should not be called in the event callback
+func (a *App) callSpecs(node DOMNode, ev *dispatchedEvent, specs []takenSpec, deferred *[]func()) {
+	for _, spec := range specs {
+		if spec.Modifiers&PreventDefault != 0 {
+			ev.PreventDefault()
+		}
+		if spec.Modifiers&StopPropagation != 0 {
+			ev.StopPropagation()
+		}
+		fn := spec.Func
+		call := func() {
+			callEventHandler(node, ev, fn, func() Navigate {
+				return a.Navigate
+			})
+		}
+		run := func(call func()) {
+			if _, ok := a.dom.(asyncDOM); ok && spec.Modifiers&Sync == 0 {
+				*deferred = append(*deferred, call)
+			} else {
+				call()
+			}
+		}
+		if spec.limiter != nil {
+			spec.limiter.handle(call, run)
+		} else {
+			run(call)
+		}
+	}
+}
+
+func (a *App) runDeferred(deferred []func()) {
+	if len(deferred) == 0 {
+		return
+	}
+	a.dom.(asyncDOM).async(func() {
+		for _, fn := range deferred {
+			fn()
+		}
+	})
+}
+
+// setGlobalEventSpecs sets window and document event handlers of element
+func (a *App) setGlobalEventSpecs(element DOMNode, specs []EventSpec) {
+	if len(specs) == 0 {
+		if id, ok := elementIDOf(element); ok {
+			a.eventsLock.Lock()
+			delete(a.globalRegistry, id)
+			a.eventsLock.Unlock()
+		}
+		return
+	}
+	id := a.ensureElementID(element)
+
+	for _, spec := range specs {
+		key := listenerKey{
+			source:  spec.Source,
+			event:   spec.Event,
+			passive: spec.Modifiers&Passive != 0,
+			capture: spec.Modifiers&Capture != 0,
+		}
+		if _, ok := a.listeners[key]; ok {
 			continue
 		}
-		wrap.Call(
-			"addEventListener",
-			event,
-			js.FuncOf(
-				func(this js.Value, args []js.Value) any {
-					go func() {
-						ev := args[0]
-						typ := ev.Get("type").String()
-						bubbles := ev.Get("bubbles").Bool()
-						for node := ev.Get("target"); !node.IsNull() && !node.IsUndefined() && !node.Equal(wrap); node = node.Get("parentNode") {
-							idValue := node.Get("__element_id__")
-							if idValue.IsUndefined() {
-								if !bubbles {
-									break
-								}
-								continue
-							}
-							id := int32(idValue.Int())
-							eventRegistryLock.RLock()
-							var specs []EventSpec
-							if evs, ok := eventRegistry[id]; ok {
-								if ss, ok := evs[typ]; ok {
-									specs = append(ss[:0:0], ss...)
-								}
-							}
-							eventRegistryLock.RUnlock()
-							for _, spec := range specs {
-								eventHandlerScope.Fork(
-									func() js.Value {
-										return node
-									},
-								).Call(spec.Func)
-							}
-							if !bubbles {
-								break
-							}
-						}
-					}()
-					return nil
-				},
-			),
-			true,
+		target := a.dom.Window()
+		if spec.Source == DocumentSource {
+			target = a.dom.Document()
+		}
+		a.listeners[key] = target.AddEventListener(
+			spec.Event,
+			func(ev DOMEvent) {
+				a.dispatchGlobalEvent(ev, key)
+			},
+			ListenerOptions{
+				Capture: key.capture,
+				Passive: key.passive,
+			},
 		)
-		eventHandlerSet[event] = true
 	}
 
-	eventRegistryLock.Lock()
-	eventRegistry[id] = specs
-	eventRegistryLock.Unlock()
+	a.eventsLock.Lock()
+	a.resetReplaced(id, a.globalRegistry[id].specs, specs)
+	a.globalRegistry[id] = globalHandlers{
+		element: element,
+		specs:   specs,
+	}
+	a.eventsLock.Unlock()
+}
+
+// dispatchGlobalEvent calls window or document handlers of all elements, in the order of element creation
+func (a *App) dispatchGlobalEvent(ev DOMEvent, key listenerKey) {
+	a.eventsLock.Lock()
+	ids := make([]int32, 0, len(a.globalRegistry))
+	for id := range a.globalRegistry {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	type call struct {
+		element DOMNode
+		specs   []takenSpec
+	}
+	var calls []call
+	for _, id := range ids {
+		handlers := a.globalRegistry[id]
+		specs := a.takeSpecs(ev, id, key.source, handlers.specs, key.capture, key.passive)
+		if len(specs) > 0 {
+			calls = append(calls, call{
+				element: handlers.element,
+				specs:   specs,
+			})
+		}
+	}
+	a.eventsLock.Unlock()
 
+	dispatched := &dispatchedEvent{
+		DOMEvent: ev,
+	}
+	var deferred []func()
+	for _, call := range calls {
+		if dispatched.stopped {
+			break
+		}
+		a.callSpecs(call.element, dispatched, call.specs, &deferred)
+	}
+	a.runDeferred(deferred)
 }
 
-func unsetEventSpecs(element js.Value) {
-	idValue := element.Get("__element_id__")
-	var id int32
-	if idValue.IsUndefined() {
-		return
-	} else {
-		id = int32(idValue.Int())
+// takeSpecs returns specs of the element to be called, and marks the Once specs as called.
+// must be called with eventsLock held
+func (a *App) takeSpecs(ev DOMEvent, id int32, source EventSource, specs []EventSpec, capture bool, passive bool) (ret []takenSpec) {
+	event := ev.Type()
+	for i, spec := range specs {
+		if spec.Source != source ||
+			spec.Event != event ||
+			(spec.Modifiers&Capture != 0) != capture ||
+			(spec.Modifiers&Passive != 0) != passive {
+			continue
+		}
+		if spec.Filter != nil && !spec.Filter(ev) {
+			continue
+		}
+		key := specKey{
+			source: source,
+			event:  event,
+			index:  i,
+		}
+		if spec.Modifiers&Once != 0 {
+			if a.onceCalled[id][key] {
+				continue
+			}
+			if a.onceCalled[id] == nil {
+				a.onceCalled[id] = make(map[specKey]bool)
+			}
+			a.onceCalled[id][key] = true
+		}
+		taken := takenSpec{
+			EventSpec: spec,
+		}
+		if spec.RateLimit != nil {
+			l, ok := a.limiters[id][key]
+			if ok && l.limit != *spec.RateLimit {
+				// changed by a render
+				l.release()
+				ok = false
+			}
+			if !ok {
+				l = &limiter{
+					clock: a.clock,
+					limit: *spec.RateLimit,
+				}
+				if a.limiters[id] == nil {
+					a.limiters[id] = make(map[specKey]*limiter)
+				}
+				a.limiters[id][key] = l
+			}
+			taken.limiter = l
+		}
+		ret = append(ret, taken)
+	}
+	return
+}
+
+// resetReplaced forgets the Once calls of specs replaced by different handlers at the same index,
+// and releases rate limiters of replaced specs or changed rate limits, dropping their pending calls.
+// must be called with eventsLock held
+func (a *App) resetReplaced(id int32, lastSpecs []EventSpec, specs []EventSpec) {
+	for i, spec := range lastSpecs {
+		key := specKey{
+			source: spec.Source,
+			event:  spec.Event,
+			index:  i,
+		}
+		replaced := i >= len(specs) || !sameHandler(spec, specs[i])
+		if replaced {
+			delete(a.onceCalled[id], key)
+		}
+		if replaced || !sameRateLimit(spec.RateLimit, specs[i].RateLimit) {
+			if l, ok := a.limiters[id][key]; ok {
+				l.release()
+				delete(a.limiters[id], key)
+			}
+		}
+	}
+}
+
+// sameHandler reports whether a and b have the same modifiers and function.
+// functions are compared by code, so closures created in every render are the same handler
+func sameHandler(a, b EventSpec) bool {
+	return a.Modifiers == b.Modifiers &&
+		a.Source == b.Source &&
+		a.Event == b.Event &&
+		funcCode(a.Func) == funcCode(b.Func)
+}
+
+func funcCode(fn any) uintptr {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		return 0
 	}
-	eventRegistryLock.Lock()
-	eventRegistry[id] = nil
-	eventRegistryLock.Unlock()
-	childNodes := element.Get("childNodes")
-	for i := childNodes.Length() - 1; i >= 0; i-- {
-		unsetEventSpecs(childNodes.Index(i))
+	return v.Pointer()
+}
+
+// dispatchedEvent records stopPropagation calls of handlers
+type dispatchedEvent struct {
+	DOMEvent
+	stopped bool
+}
+
+// StopPropagation also stops the other root listeners of the event, like the passive one, which start their own walks
+func (e *dispatchedEvent) StopPropagation() {
+	e.stopped = true
+	e.DOMEvent.StopImmediatePropagation()
+}
+
+func (e *dispatchedEvent) StopImmediatePropagation() {
+	e.StopPropagation()
+}
+
+func handlerDefs(node DOMNode) []any {
+	defs := []any{
+		func() DOMNode {
+			return node
+		},
+	}
+	if n, ok := node.(nodeDefs); ok {
+		defs = append(defs, n.defs()...)
+	}
+	return defs
+}
+
+func callEventHandler(node DOMNode, ev DOMEvent, fn any, defs ...any) {
+	defs = append(defs, handlerDefs(node)...)
+	defs = append(defs, eventDefs(ev, node)...)
+	eventHandlerScope.Fork(defs...).Call(fn)
+}
+
+func (a *App) unsetEventSpecs(element DOMNode) {
+	id, ok := elementIDOf(element)
+	if !ok {
+		return
 	}
+	a.eventsLock.Lock()
+	delete(a.eventRegistry, id)
+	delete(a.onceCalled, id)
+	a.eventsLock.Unlock()
 }
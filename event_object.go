@@ -0,0 +1,116 @@
+package domui
+
+// Event is the event being handled, provided to event handlers
+type Event struct {
+	DOMEvent
+	// CurrentTarget is the element of the handler
+	CurrentTarget DOMNode
+}
+
+// MouseEvent is provided to event handlers, fields are zero if the event is not a mouse event
+type MouseEvent struct {
+	Event
+	ClientX  float64
+	ClientY  float64
+	OffsetX  float64
+	OffsetY  float64
+	Button   int
+	Buttons  int
+	AltKey   bool
+	CtrlKey  bool
+	ShiftKey bool
+	MetaKey  bool
+}
+
+// KeyboardEvent is provided to event handlers, fields are zero if the event is not a keyboard event
+type KeyboardEvent struct {
+	Event
+	Key         string
+	Code        string
+	Repeat      bool
+	IsComposing bool
+	AltKey      bool
+	CtrlKey     bool
+	ShiftKey    bool
+	MetaKey     bool
+}
+
+// InputEvent is provided to event handlers
+type InputEvent struct {
+	Event
+	Data      string
+	InputType string
+	// Value is the value of the target element
+	Value string
+}
+
+func eventDefs(ev DOMEvent, node DOMNode) []any {
+	event := Event{
+		DOMEvent:      ev,
+		CurrentTarget: node,
+	}
+	return []any{
+		func() Event {
+			return event
+		},
+		func() MouseEvent {
+			return MouseEvent{
+				Event:    event,
+				ClientX:  eventFloat(ev, "clientX"),
+				ClientY:  eventFloat(ev, "clientY"),
+				OffsetX:  eventFloat(ev, "offsetX"),
+				OffsetY:  eventFloat(ev, "offsetY"),
+				Button:   int(eventFloat(ev, "button")),
+				Buttons:  int(eventFloat(ev, "buttons")),
+				AltKey:   eventBool(ev, "altKey"),
+				CtrlKey:  eventBool(ev, "ctrlKey"),
+				ShiftKey: eventBool(ev, "shiftKey"),
+				MetaKey:  eventBool(ev, "metaKey"),
+			}
+		},
+		func() KeyboardEvent {
+			return KeyboardEvent{
+				Event:       event,
+				Key:         eventString(ev, "key"),
+				Code:        eventString(ev, "code"),
+				Repeat:      eventBool(ev, "repeat"),
+				IsComposing: eventBool(ev, "isComposing"),
+				AltKey:      eventBool(ev, "altKey"),
+				CtrlKey:     eventBool(ev, "ctrlKey"),
+				ShiftKey:    eventBool(ev, "shiftKey"),
+				MetaKey:     eventBool(ev, "metaKey"),
+			}
+		},
+		func() InputEvent {
+			e := InputEvent{
+				Event:     event,
+				Data:      eventString(ev, "data"),
+				InputType: eventString(ev, "inputType"),
+			}
+			if target := ev.Target(); target != nil {
+				e.Value = propString(target, "value")
+			}
+			return e
+		},
+	}
+}
+
+func eventString(ev DOMEvent, name string) string {
+	s, _ := ev.Get(name).(string)
+	return s
+}
+
+func eventFloat(ev DOMEvent, name string) float64 {
+	switch v := ev.Get(name).(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	}
+	return 0
+}
+
+func eventBool(ev DOMEvent, name string) bool {
+	b, _ := ev.Get(name).(bool)
+	return b
+}
@@ -0,0 +1,399 @@
+package domui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMultipleApps(t *testing.T) {
+	dom := NewMemDOM()
+	clicks := make(map[string]int)
+	newApp := func(name string) *App {
+		parent := dom.CreateElement("div")
+		dom.Body().AppendChild(parent)
+		return NewDOMApp(
+			dom,
+			parent,
+			func() RootElement {
+				return Div(
+					P(
+						OnClick(func() {
+							clicks[name]++
+						}),
+					),
+				)
+			},
+		)
+	}
+
+	app1 := newApp("foo")
+	defer app1.Close()
+	app2 := newApp("bar")
+	defer app2.Close()
+
+	app1.element.ChildNode(0).(*MemNode).Click()
+	if clicks["foo"] != 1 || clicks["bar"] != 0 {
+		t.Fatalf("got %v", clicks)
+	}
+	app2.element.ChildNode(0).(*MemNode).Click()
+	if clicks["foo"] != 1 || clicks["bar"] != 1 {
+		t.Fatalf("got %v", clicks)
+	}
+
+	// closing one app does not affect the other
+	app1.Close()
+	app2.element.ChildNode(0).(*MemNode).Click()
+	if clicks["foo"] != 1 || clicks["bar"] != 2 {
+		t.Fatalf("got %v", clicks)
+	}
+}
+
+func TestNestedApps(t *testing.T) {
+	dom := NewMemDOM()
+	parent := dom.CreateElement("div")
+	dom.Body().AppendChild(parent)
+	clicks := make(map[string]int)
+	outer := NewDOMApp(
+		dom,
+		parent,
+		func() RootElement {
+			return Div(
+				P(
+					OnClick(func() {
+						clicks["outer"]++
+					}),
+				),
+				Div(),
+			)
+		},
+	)
+	defer outer.Close()
+
+	// the first element ids of both apps collided when ids were allocated per app
+	inner := NewDOMApp(
+		dom,
+		outer.element.ChildNode(1),
+		func() RootElement {
+			return Tag("button")(
+				OnClick(func() {
+					clicks["inner"]++
+				}),
+			)
+		},
+	)
+	defer inner.Close()
+
+	inner.element.(*MemNode).Click()
+	if clicks["outer"] != 0 || clicks["inner"] != 1 {
+		t.Fatalf("got %v", clicks)
+	}
+	outer.element.ChildNode(0).(*MemNode).Click()
+	if clicks["outer"] != 1 || clicks["inner"] != 1 {
+		t.Fatalf("got %v", clicks)
+	}
+}
+
+func TestTypedEvents(t *testing.T) {
+	var key string
+	var clientX float64
+	var currentTarget, target DOMNode
+	var value string
+	WithTestApp(
+		t,
+		func(app *App) {
+			div := app.element
+			input := div.ChildNode(0).(*MemNode)
+
+			input.DispatchEvent(NewMemEvent("keydown", true).Set("key", "Enter"))
+			if key != "Enter" {
+				t.Fatalf("got %q", key)
+			}
+
+			input.DispatchEvent(NewMemEvent("click", true).Set("clientX", 42.0))
+			if clientX != 42 {
+				t.Fatalf("got %v", clientX)
+			}
+			if !currentTarget.Equal(div) || !target.Equal(input) {
+				t.Fatal()
+			}
+
+			input.SetProperty("value", "foo")
+			input.DispatchEvent(NewMemEvent("input", true))
+			if value != "foo" {
+				t.Fatalf("got %q", value)
+			}
+		},
+		func() RootElement {
+			return Div(
+				Tag("input")(
+					On("keydown")(func(ev KeyboardEvent) {
+						key = ev.Key
+					}),
+					On("input")(func(ev InputEvent) {
+						value = ev.Value
+					}),
+				),
+				OnClick(func(ev MouseEvent, e Event) {
+					clientX = ev.ClientX
+					currentTarget = e.CurrentTarget
+					target = e.Target()
+				}),
+			)
+		},
+	)
+}
+
+func TestEventModifiers(t *testing.T) {
+	var seq []string
+	WithTestApp(
+		t,
+		func(app *App) {
+			outer := app.element
+			inner := outer.ChildNode(0).(*MemNode)
+
+			// capture, stop propagation
+			if !inner.DispatchEvent(NewMemEvent("click", true)) {
+				t.Fatal()
+			}
+			if len(seq) != 2 || seq[0] != "outer capture" || seq[1] != "inner" {
+				t.Fatalf("got %v", seq)
+			}
+
+			// prevent default
+			if inner.DispatchEvent(NewMemEvent("submit", true)) {
+				t.Fatal()
+			}
+			// no effect in passive listeners
+			if !inner.DispatchEvent(NewMemEvent("wheel", true)) {
+				t.Fatal()
+			}
+
+			// once
+			seq = seq[:0]
+			inner.DispatchEvent(NewMemEvent("mousedown", true))
+			inner.DispatchEvent(NewMemEvent("mousedown", true))
+			app.Update(func() int {
+				return 1
+			})
+			app.Render()
+			inner.DispatchEvent(NewMemEvent("mousedown", true))
+			if len(seq) != 1 || seq[0] != "once" {
+				t.Fatalf("got %v", seq)
+			}
+
+			// stopping propagation skips passive handlers of ancestors
+			seq = seq[:0]
+			inner.DispatchEvent(NewMemEvent("touchstart", true))
+			if len(seq) != 1 || seq[0] != "inner touch" {
+				t.Fatalf("got %v", seq)
+			}
+		},
+		func() int {
+			return 0
+		},
+		func(n int) RootElement {
+			return Div(
+				P(
+					OnClick(func() {
+						seq = append(seq, "inner")
+					}).With(StopPropagation),
+					On("submit", PreventDefault)(func() {}),
+					On("wheel", Passive, PreventDefault)(func() {}),
+					On("mousedown", Once)(func() {
+						seq = append(seq, "once")
+					}),
+					On("mousedown")(func(ev Event) {
+						// stops the walk
+						ev.StopPropagation()
+					}),
+					On("touchstart", StopPropagation)(func() {
+						seq = append(seq, "inner touch")
+					}),
+				),
+				On("touchstart", Passive)(func() {
+					seq = append(seq, "passive")
+				}),
+				On("click", Capture)(func() {
+					seq = append(seq, "outer capture")
+				}),
+				OnClick(func() {
+					seq = append(seq, "outer")
+				}),
+				On("mousedown")(func() {
+					seq = append(seq, "stopped")
+				}),
+			)
+		},
+	)
+}
+
+func TestOnceReplaced(t *testing.T) {
+	var seq []string
+	WithTestApp(
+		t,
+		func(app *App) {
+			p := app.element.(*MemNode)
+			p.DispatchEvent(NewMemEvent("mousedown", true))
+			p.DispatchEvent(NewMemEvent("mousedown", true))
+			// a different handler at the same index
+			app.Update(func() int {
+				return 1
+			})
+			app.Render()
+			p.DispatchEvent(NewMemEvent("mousedown", true))
+			p.DispatchEvent(NewMemEvent("mousedown", true))
+			if len(seq) != 2 || seq[0] != "a" || seq[1] != "b" {
+				t.Fatalf("got %v", seq)
+			}
+		},
+		func() int {
+			return 0
+		},
+		func(n int) RootElement {
+			fn := func() {
+				seq = append(seq, "a")
+			}
+			if n > 0 {
+				fn = func() {
+					seq = append(seq, "b")
+				}
+			}
+			return P(
+				On("mousedown", Once)(fn),
+			)
+		},
+	)
+}
+
+func TestSyncFocusHandler(t *testing.T) {
+	focused := 0
+	WithTestApp(
+		t,
+		func(app *App) {
+			app.Update(func() int {
+				return 1
+			})
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				app.Render()
+			}()
+			select {
+			case <-done:
+			case <-time.After(time.Second * 5):
+				t.Fatal("deadlock")
+			}
+			if focused != 1 {
+				t.Fatalf("got %d", focused)
+			}
+			if !app.dom.ActiveElement().Equal(app.element.ChildNode(0)) {
+				t.Fatal()
+			}
+		},
+		func() int {
+			return 0
+		},
+		func(n int, update Update) RootElement {
+			return Div(
+				Tag("input")(
+					If(n > 0, Focus),
+					On("focus", Sync)(func() {
+						focused++
+						// focused after the render, not holding the lock
+						update(func() int {
+							return 2
+						})
+					}),
+				),
+			)
+		},
+	)
+}
+
+func TestGlobalEvents(t *testing.T) {
+	calls := make(map[string]int)
+	var declaring DOMNode
+	WithTestApp(
+		t,
+		func(app *App) {
+			dom := app.dom.(*MemDOM)
+			window := dom.Window().(*MemNode)
+			document := dom.Document().(*MemNode)
+
+			window.DispatchEvent(NewMemEvent("resize", false))
+			document.DispatchEvent(NewMemEvent("visibilitychange", true))
+			// bubbles to window
+			dom.Body().DispatchEvent(NewMemEvent("keydown", true))
+			if calls["resize"] != 1 || calls["visibilitychange"] != 1 || calls["keydown"] != 1 {
+				t.Fatalf("got %v", calls)
+			}
+			if !declaring.Equal(app.element.ChildNode(0)) {
+				t.Fatal()
+			}
+
+			// unmounted
+			app.Update(func() bool {
+				return false
+			})
+			app.Render()
+			window.DispatchEvent(NewMemEvent("resize", false))
+			document.DispatchEvent(NewMemEvent("visibilitychange", true))
+			if calls["resize"] != 1 || calls["visibilitychange"] != 1 {
+				t.Fatalf("got %v", calls)
+			}
+		},
+		func() bool {
+			return true
+		},
+		func(show bool) RootElement {
+			return Div(
+				If(show, P(
+					OnWindow("resize")(func(node DOMNode) {
+						calls["resize"]++
+						declaring = node
+					}),
+					OnWindow("keydown")(func(ev KeyboardEvent) {
+						calls["keydown"]++
+					}),
+					OnDocument("visibilitychange")(func() {
+						calls["visibilitychange"]++
+					}),
+				)),
+			)
+		},
+	)
+}
+
+func TestNonBubblingEvents(t *testing.T) {
+	var seq []string
+	WithTestApp(
+		t,
+		func(app *App) {
+			inner := app.element.ChildNode(0).(*MemNode)
+			inner.DispatchEvent(NewMemEvent("mouseenter", false))
+			if len(seq) != 2 || seq[0] != "outer capture" || seq[1] != "inner" {
+				t.Fatalf("got %v", seq)
+			}
+			seq = seq[:0]
+			app.element.(*MemNode).DispatchEvent(NewMemEvent("mouseenter", false))
+			if len(seq) != 2 || seq[0] != "outer capture" || seq[1] != "outer" {
+				t.Fatalf("got %v", seq)
+			}
+		},
+		func() RootElement {
+			return Div(
+				P(
+					On("mouseenter")(func() {
+						seq = append(seq, "inner")
+					}),
+				),
+				On("mouseenter")(func() {
+					seq = append(seq, "outer")
+				}),
+				On("mouseenter", Capture)(func() {
+					seq = append(seq, "outer capture")
+				}),
+			)
+		},
+	)
+}
@@ -0,0 +1,85 @@
+package domui
+
+// Fragment groups nodes as siblings without a wrapper element.
+// Only child nodes and keys are applicable to fragments
+func Fragment(specs ...Spec) *Node {
+	node := &Node{
+		Kind: FragmentNode,
+	}
+
+	for _, spec := range specs {
+		node.ApplySpec(spec)
+	}
+
+	return node
+}
+
+// rootNode converts a RootElement to node. Specs, Lazy and nil are rendered as fragments
+func rootNode(root RootElement) *Node {
+	if node, ok := root.(*Node); ok && node != nil {
+		return node
+	}
+	return Fragment(root)
+}
+
+// children returns the child nodes to render.
+// empty fragments render an empty text node, to have a position in the parent
+func (n *Node) children() []*Node {
+	if n.Kind == FragmentNode && len(n.childNodes) == 0 {
+		return []*Node{
+			{
+				Kind: TextNode,
+			},
+		}
+	}
+	return n.childNodes
+}
+
+// span returns the number of sibling DOM nodes rendered from n
+func (n *Node) span() int {
+	if n == nil || n.Kind != FragmentNode {
+		return 1
+	}
+	return spanOf(n.children())
+}
+
+func spanOf(nodes []*Node) int {
+	ret := 0
+	for _, node := range nodes {
+		ret += node.span()
+	}
+	return ret
+}
+
+// firstElement returns the first DOM node of element created by n.ToElement
+func (n *Node) firstElement(element DOMNode) DOMNode {
+	if n.Kind == FragmentNode {
+		return element.ChildNode(0)
+	}
+	return element
+}
+
+// skipElements returns the n-th next sibling of element
+func skipElements(element DOMNode, n int) DOMNode {
+	for ; n > 0 && element != nil; n-- {
+		element = element.NextSibling()
+	}
+	return element
+}
+
+// siblingElements returns element and its next siblings, n in total
+func siblingElements(element DOMNode, n int) []DOMNode {
+	ret := make([]DOMNode, 0, n)
+	for ; n > 0 && element != nil; n-- {
+		ret = append(ret, element)
+		element = element.NextSibling()
+	}
+	return ret
+}
+
+// removeElements releases and removes element and its next siblings, n in total
+func (a *App) removeElements(element DOMNode, n int) {
+	for _, e := range siblingElements(element, n) {
+		a.removeElement(e)
+	}
+}
@@ -0,0 +1,239 @@
+package domui
+
+import (
+	"testing"
+)
+
+func TestFragmentRoot(t *testing.T) {
+	type Root struct {
+		Spec
+	}
+	WithTestApp(
+		t,
+		func(app *App) {
+			for _, c := range []struct {
+				root Spec
+				html string
+			}{
+				{
+					Specs{P(Text("foo")), Text("bar")},
+					`<p>foo</p>bar`,
+				},
+				{
+					nil,
+					``,
+				},
+				{
+					Lazy(func() Spec {
+						return Div(Text("foo"))
+					}),
+					`<div>foo</div>`,
+				},
+				{
+					Fragment(P(), Fragment(), Fragment(Text("foo"), P())),
+					`<p></p>foo<p></p>`,
+				},
+				{
+					Div(),
+					`<div></div>`,
+				},
+				{
+					Specs{Div(), Div(Text("foo")), Div()},
+					`<div></div><div>foo</div><div></div>`,
+				},
+			} {
+				app.Update(func() Root {
+					return Root{c.root}
+				})
+				app.Render()
+				if html := app.HTML(); html != c.html {
+					t.Fatalf("got %s", html)
+				}
+				if html := app.wrapElement.OuterHTML(); html != "<div>"+c.html+"</div>" {
+					t.Fatalf("got %s", html)
+				}
+			}
+		},
+		func() Root {
+			return Root{}
+		},
+		func(root Root) RootElement {
+			return root.Spec
+		},
+	)
+}
+
+func TestFragmentChildren(t *testing.T) {
+	unmounted := 0
+	WithTestApp(
+		t,
+		func(app *App) {
+			first := app.element.ChildNode(0)
+			last := app.element.ChildNode(app.element.NumChildNodes() - 1)
+			for _, c := range []struct {
+				n    int
+				html string
+			}{
+				{0, `<div><p>first</p><p>last</p></div>`},
+				{3, `<div><p>first</p><span>0</span><span>1</span><span>2</span><p>last</p></div>`},
+				{1, `<div><p>first</p><span>0</span><p>last</p></div>`},
+				{2, `<div><p>first</p><span>0</span><span>1</span><p>last</p></div>`},
+				{0, `<div><p>first</p><p>last</p></div>`},
+			} {
+				app.Update(func() int {
+					return c.n
+				})
+				app.Render()
+				if html := app.HTML(); html != c.html {
+					t.Fatalf("got %s", html)
+				}
+				// siblings are patched, not replaced
+				if !app.element.ChildNode(0).Equal(first) ||
+					!app.element.ChildNode(app.element.NumChildNodes()-1).Equal(last) {
+					t.Fatal()
+				}
+			}
+			if unmounted != 4 {
+				t.Fatalf("got %d", unmounted)
+			}
+		},
+		func() int {
+			return 0
+		},
+		func(n int) RootElement {
+			var specs Specs
+			for i := 0; i < n; i++ {
+				specs = append(specs, Tag("span")(
+					Text("%d", i),
+					OnUnmount(func() {
+						unmounted++
+					}),
+				))
+			}
+			return Div(
+				P(Text("first")),
+				Fragment(specs...),
+				P(Text("last")),
+			)
+		},
+	)
+}
+
+func TestKeyedFragments(t *testing.T) {
+	WithTestApp(
+		t,
+		func(app *App) {
+			elements := make(map[string]DOMNode)
+			for i := 0; i < app.element.NumChildNodes(); i++ {
+				e := app.element.ChildNode(i)
+				elements[e.OuterHTML()] = e
+			}
+			for _, list := range [][]int{
+				{2, 1, 0},
+				{1, 3},
+				{},
+				{0, 1},
+			} {
+				app.Update(func() []int {
+					return list
+				})
+				app.Render()
+				expected := "<div>"
+				for _, i := range list {
+					expected += sp("<dt>%d</dt><dd>%d</dd>", i, i)
+				}
+				expected += "</div>"
+				if html := app.HTML(); html != expected {
+					t.Fatalf("got %s", html)
+				}
+				current := make(map[string]DOMNode)
+				for i := 0; i < app.element.NumChildNodes(); i++ {
+					e := app.element.ChildNode(i)
+					if last, ok := elements[e.OuterHTML()]; ok && !last.Equal(e) {
+						t.Fatalf("%s not reused", e.OuterHTML())
+					}
+					current[e.OuterHTML()] = e
+				}
+				elements = current
+			}
+		},
+		func() []int {
+			return []int{0, 1, 2}
+		},
+		func(list []int) RootElement {
+			return Div(
+				For(list, func(i int) Spec {
+					return Fragment(
+						Key(i),
+						Tag("dt")(Text("%d", i)),
+						Tag("dd")(Text("%d", i)),
+					)
+				}),
+			)
+		},
+	)
+}
+
+func TestHydrateFragment(t *testing.T) {
+	dom := NewMemDOM()
+	parent := dom.CreateElement("div")
+	dom.Body().AppendChild(parent)
+
+	defs := []any{
+		func() RootElement {
+			return Specs{
+				P(Text("foo")),
+				Div(
+					Fragment(),
+					Fragment(Text("bar"), Tag("br")()),
+					P(),
+				),
+			}
+		},
+	}
+	html, err := RenderToString(defs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if html != `<p>foo</p><div>bar<br><p></p></div>` {
+		t.Fatalf("got %s", html)
+	}
+
+	// server rendered markup
+	p := dom.CreateElement("p")
+	p.AppendChild(dom.CreateTextNode("foo"))
+	parent.AppendChild(p)
+	div := dom.CreateElement("div")
+	div.AppendChild(dom.CreateTextNode("bar"))
+	div.AppendChild(dom.CreateElement("br"))
+	div.AppendChild(dom.CreateElement("p"))
+	parent.AppendChild(div)
+
+	var mismatches []HydrationMismatch
+	app := NewDOMApp(
+		dom,
+		parent,
+		append(defs,
+			func() Hydrate {
+				return true
+			},
+			func() OnHydrationMismatch {
+				return func(mismatch HydrationMismatch) {
+					mismatches = append(mismatches, mismatch)
+				}
+			},
+		)...,
+	)
+	defer app.Close()
+
+	if len(mismatches) > 0 {
+		t.Fatalf("got %+v", mismatches)
+	}
+	if got := app.HTML(); got != html {
+		t.Fatalf("got %s", got)
+	}
+	// adopted
+	if !app.element.Equal(p) || !app.element.NextSibling().Equal(div) {
+		t.Fatal()
+	}
+}
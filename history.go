@@ -0,0 +1,138 @@
+package domui
+
+import (
+	"net/url"
+	"sync"
+)
+
+// History stores the location of the App, like the History API of browsers
+type History interface {
+	// Location returns the current path, with query and fragment
+	Location() string
+	// Push adds location to the history. location can be relative to the current one
+	Push(location string)
+	// Replace replaces the current location of the history
+	Replace(location string)
+	// Listen calls fn after the location is changed outside the App, like by back and forward buttons
+	Listen(fn func()) (stop func())
+	// Href returns the href attribute value of links to location
+	Href(location string) string
+}
+
+// HashRouting stores the location in the URL fragment, like /#/users/1, for servers not serving the App for all paths.
+// It is also used when the History API is not available
+type HashRouting bool
+
+func (_ Def) HashRouting() HashRouting {
+	return false
+}
+
+// historyDOM is implemented by DOMs that provide the history of the document
+type historyDOM interface {
+	history(hash bool) History
+}
+
+func (_ Def) History(dom DOM, hash HashRouting) History {
+	if d, ok := dom.(historyDOM); ok {
+		return d.history(bool(hash))
+	}
+	return NewMemoryHistory("/")
+}
+
+// resolveLocation resolves location relative to base
+func resolveLocation(base string, location string) string {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return location
+	}
+	ref, err := url.Parse(location)
+	if err != nil {
+		return location
+	}
+	return baseURL.ResolveReference(ref).String()
+}
+
+// MemoryHistory is a History not backed by the browser, for headless rendering and tests
+type MemoryHistory struct {
+	mu        sync.Mutex
+	entries   []string
+	index     int
+	listeners map[int]func()
+	serial    int
+}
+
+var _ History = new(MemoryHistory)
+
+// NewMemoryHistory creates a MemoryHistory at location
+func NewMemoryHistory(location string) *MemoryHistory {
+	return &MemoryHistory{
+		entries:   []string{location},
+		listeners: make(map[int]func()),
+	}
+}
+
+func (h *MemoryHistory) Location() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.entries[h.index]
+}
+
+func (h *MemoryHistory) Push(location string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	location = resolveLocation(h.entries[h.index], location)
+	h.entries = append(h.entries[:h.index+1], location)
+	h.index++
+}
+
+func (h *MemoryHistory) Replace(location string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries[h.index] = resolveLocation(h.entries[h.index], location)
+}
+
+func (h *MemoryHistory) Listen(fn func()) (stop func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.serial++
+	id := h.serial
+	h.listeners[id] = fn
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.listeners, id)
+	}
+}
+
+func (h *MemoryHistory) Href(location string) string {
+	return location
+}
+
+// Go moves by delta entries in the history and calls the listeners, like the back and forward buttons
+func (h *MemoryHistory) Go(delta int) {
+	h.mu.Lock()
+	i := h.index + delta
+	if i < 0 || i >= len(h.entries) || delta == 0 {
+		h.mu.Unlock()
+		return
+	}
+	h.index = i
+	listeners := make([]func(), 0, len(h.listeners))
+	for _, fn := range h.listeners {
+		listeners = append(listeners, fn)
+	}
+	h.mu.Unlock()
+	for _, fn := range listeners {
+		fn()
+	}
+}
+
+// Back moves to the previous entry
+func (h *MemoryHistory) Back() {
+	h.Go(-1)
+}
+
+// Forward moves to the next entry
+func (h *MemoryHistory) Forward() {
+	h.Go(1)
+}
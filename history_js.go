@@ -0,0 +1,79 @@
+package domui
+
+import (
+	"strings"
+	"syscall/js"
+)
+
+func (_ jsDOM) history(hash bool) History {
+	if hash || !global.Get("history").Get("pushState").Truthy() {
+		return hashHistory{}
+	}
+	return browserHistory{}
+}
+
+func listenWindow(event string, fn func()) (stop func()) {
+	f := js.FuncOf(func(this js.Value, args []js.Value) any {
+		// callbacks must not block the javascript event loop
+		go fn()
+		return nil
+	})
+	global.Call("addEventListener", event, f)
+	return func() {
+		global.Call("removeEventListener", event, f)
+		f.Release()
+	}
+}
+
+// browserHistory stores locations in URL paths with the History API
+type browserHistory struct{}
+
+func (_ browserHistory) Location() string {
+	location := global.Get("location")
+	return location.Get("pathname").String() +
+		location.Get("search").String() +
+		location.Get("hash").String()
+}
+
+func (_ browserHistory) Push(location string) {
+	global.Get("history").Call("pushState", nil, "", location)
+}
+
+func (_ browserHistory) Replace(location string) {
+	global.Get("history").Call("replaceState", nil, "", location)
+}
+
+func (_ browserHistory) Listen(fn func()) (stop func()) {
+	return listenWindow("popstate", fn)
+}
+
+func (_ browserHistory) Href(location string) string {
+	return location
+}
+
+// hashHistory stores locations in URL fragments
+type hashHistory struct{}
+
+func (_ hashHistory) Location() string {
+	location := strings.TrimPrefix(global.Get("location").Get("hash").String(), "#")
+	if location == "" {
+		return "/"
+	}
+	return location
+}
+
+func (h hashHistory) Push(location string) {
+	global.Get("location").Set("hash", resolveLocation(h.Location(), location))
+}
+
+func (h hashHistory) Replace(location string) {
+	global.Get("location").Call("replace", "#"+resolveLocation(h.Location(), location))
+}
+
+func (_ hashHistory) Listen(fn func()) (stop func()) {
+	return listenWindow("hashchange", fn)
+}
+
+func (h hashHistory) Href(location string) string {
+	return "#" + resolveLocation(h.Location(), location)
+}
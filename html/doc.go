@@ -0,0 +1,11 @@
+// Package html provides typed constructors of HTML elements, attributes, CSS properties and events.
+//
+// Elements take the plain names, like Div and Input.
+// Attributes named like elements have an Attr suffix, like TitleAttr,
+// and CSS properties named like elements or attributes have a Style suffix, like WidthStyle.
+// Event handlers have an On prefix, like OnClick.
+//
+// The constructors are generated from spec.txt by gen.go.
+package html
+
+//go:generate go run gen.go
@@ -0,0 +1,224 @@
+//go:build ignore
+
+// gen generates html.go from spec.txt
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+)
+
+var (
+	inPath  = flag.String("in", "spec.txt", "spec list")
+	outPath = flag.String("out", "html.go", "output file")
+)
+
+type entry struct {
+	Kind   string
+	Name   string
+	Type   string
+	GoName string
+}
+
+func main() {
+	flag.Parse()
+	entries, err := parse(*inPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	src, err := generate(entries)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*outPath, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+var attrTypes = map[string]string{
+	"string":    "string",
+	"bool":      "bool",
+	"int":       "int",
+	"float":     "float64",
+	"truefalse": "bool",
+}
+
+func parse(path string) (entries []entry, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		bad := func() error {
+			return fmt.Errorf("%s:%d: bad line: %s", path, lineNum, line)
+		}
+		if len(fields) < 2 {
+			return nil, bad()
+		}
+		e := entry{
+			Kind: fields[0],
+			Name: fields[1],
+		}
+		rest := fields[2:]
+		switch e.Kind {
+		case "element", "css", "event":
+		case "attr":
+			if len(rest) == 0 {
+				return nil, bad()
+			}
+			e.Type = rest[0]
+			if _, ok := attrTypes[e.Type]; !ok {
+				return nil, bad()
+			}
+			rest = rest[1:]
+		default:
+			return nil, bad()
+		}
+		switch len(rest) {
+		case 0:
+			e.GoName = camel(e.Name)
+		case 1:
+			e.GoName = rest[0]
+		default:
+			return nil, bad()
+		}
+		if e.Kind == "event" {
+			e.GoName = "On" + e.GoName
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// resolve name collisions
+	used := make(map[string]string)
+	for _, suffixKind := range []struct {
+		kind   string
+		suffix string
+	}{
+		{"element", ""},
+		{"event", ""},
+		{"attr", "Attr"},
+		{"css", "Style"},
+	} {
+		for i, e := range entries {
+			if e.Kind != suffixKind.kind {
+				continue
+			}
+			if _, ok := used[e.GoName]; ok && suffixKind.suffix != "" {
+				e.GoName += suffixKind.suffix
+				entries[i] = e
+			}
+			if kind, ok := used[e.GoName]; ok {
+				return nil, fmt.Errorf("duplicated name %s of %s %s and %s", e.GoName, e.Kind, e.Name, kind)
+			}
+			used[e.GoName] = e.Kind + " " + e.Name
+		}
+	}
+
+	return entries, nil
+}
+
+func camel(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "-") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]))
+		b.WriteString(part[1:])
+	}
+	return b.String()
+}
+
+func generate(entries []entry) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	w := func(format string, args ...any) {
+		fmt.Fprintf(buf, format, args...)
+	}
+
+	usesStrconv := false
+	for _, e := range entries {
+		if e.Type == "truefalse" {
+			usesStrconv = true
+		}
+	}
+
+	w("// Code generated by gen.go from spec.txt. DO NOT EDIT.\n\n")
+	w("package html\n\n")
+	w("import (\n")
+	if usesStrconv {
+		w("%q\n\n", "strconv")
+	}
+	w("%q\n", "github.com/reusee/domui")
+	w(")\n\n")
+
+	for _, section := range []struct {
+		kind  string
+		title string
+	}{
+		{"element", "elements"},
+		{"attr", "attributes"},
+		{"css", "css properties"},
+		{"event", "events"},
+	} {
+		w("// %s\n\n", section.title)
+		for _, e := range entries {
+			if e.Kind != section.kind {
+				continue
+			}
+			switch e.Kind {
+
+			case "element":
+				w("// %s creates a <%s> element\n", e.GoName, e.Name)
+				w("func %s(specs ...domui.Spec) *domui.Node {\n", e.GoName)
+				w("return domui.Tag(%q)(specs...)\n", e.Name)
+				w("}\n\n")
+
+			case "attr":
+				w("// %s sets the %s attribute\n", e.GoName, e.Name)
+				w("func %s(value %s) domui.AttrSpec {\n", e.GoName, attrTypes[e.Type])
+				if e.Type == "truefalse" {
+					w("return domui.Attr(%q)(strconv.FormatBool(value))\n", e.Name)
+				} else {
+					w("return domui.Attr(%q)(value)\n", e.Name)
+				}
+				w("}\n\n")
+
+			case "css":
+				w("// %s sets the %s css property\n", e.GoName, e.Name)
+				w("func %s(format string, args ...any) domui.StyleSpec {\n", e.GoName)
+				w("return domui.Style(%q)(format, args...)\n", e.Name)
+				w("}\n\n")
+
+			case "event":
+				w("// %s handles the %s event\n", e.GoName, e.Name)
+				w("func %s(fn any) domui.EventSpec {\n", e.GoName)
+				w("return domui.On(%q)(fn)\n", e.Name)
+				w("}\n\n")
+
+			}
+		}
+	}
+
+	return format.Source(buf.Bytes())
+}
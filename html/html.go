@@ -0,0 +1,2027 @@
+// Code generated by gen.go from spec.txt. DO NOT EDIT.
+
+package html
+
+import (
+	"strconv"
+
+	"github.com/reusee/domui"
+)
+
+// elements
+
+// A creates a <a> element
+func A(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("a")(specs...)
+}
+
+// Abbr creates a <abbr> element
+func Abbr(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("abbr")(specs...)
+}
+
+// Address creates a <address> element
+func Address(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("address")(specs...)
+}
+
+// Area creates a <area> element
+func Area(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("area")(specs...)
+}
+
+// Article creates a <article> element
+func Article(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("article")(specs...)
+}
+
+// Aside creates a <aside> element
+func Aside(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("aside")(specs...)
+}
+
+// Audio creates a <audio> element
+func Audio(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("audio")(specs...)
+}
+
+// B creates a <b> element
+func B(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("b")(specs...)
+}
+
+// Base creates a <base> element
+func Base(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("base")(specs...)
+}
+
+// Bdi creates a <bdi> element
+func Bdi(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("bdi")(specs...)
+}
+
+// Bdo creates a <bdo> element
+func Bdo(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("bdo")(specs...)
+}
+
+// Blockquote creates a <blockquote> element
+func Blockquote(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("blockquote")(specs...)
+}
+
+// Body creates a <body> element
+func Body(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("body")(specs...)
+}
+
+// Br creates a <br> element
+func Br(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("br")(specs...)
+}
+
+// Button creates a <button> element
+func Button(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("button")(specs...)
+}
+
+// Canvas creates a <canvas> element
+func Canvas(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("canvas")(specs...)
+}
+
+// Caption creates a <caption> element
+func Caption(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("caption")(specs...)
+}
+
+// Cite creates a <cite> element
+func Cite(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("cite")(specs...)
+}
+
+// Code creates a <code> element
+func Code(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("code")(specs...)
+}
+
+// Col creates a <col> element
+func Col(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("col")(specs...)
+}
+
+// Colgroup creates a <colgroup> element
+func Colgroup(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("colgroup")(specs...)
+}
+
+// Data creates a <data> element
+func Data(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("data")(specs...)
+}
+
+// Datalist creates a <datalist> element
+func Datalist(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("datalist")(specs...)
+}
+
+// Dd creates a <dd> element
+func Dd(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("dd")(specs...)
+}
+
+// Del creates a <del> element
+func Del(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("del")(specs...)
+}
+
+// Details creates a <details> element
+func Details(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("details")(specs...)
+}
+
+// Dfn creates a <dfn> element
+func Dfn(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("dfn")(specs...)
+}
+
+// Dialog creates a <dialog> element
+func Dialog(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("dialog")(specs...)
+}
+
+// Div creates a <div> element
+func Div(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("div")(specs...)
+}
+
+// Dl creates a <dl> element
+func Dl(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("dl")(specs...)
+}
+
+// Dt creates a <dt> element
+func Dt(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("dt")(specs...)
+}
+
+// Em creates a <em> element
+func Em(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("em")(specs...)
+}
+
+// Embed creates a <embed> element
+func Embed(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("embed")(specs...)
+}
+
+// Fieldset creates a <fieldset> element
+func Fieldset(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("fieldset")(specs...)
+}
+
+// FigCaption creates a <figcaption> element
+func FigCaption(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("figcaption")(specs...)
+}
+
+// Figure creates a <figure> element
+func Figure(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("figure")(specs...)
+}
+
+// Footer creates a <footer> element
+func Footer(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("footer")(specs...)
+}
+
+// Form creates a <form> element
+func Form(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("form")(specs...)
+}
+
+// H1 creates a <h1> element
+func H1(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("h1")(specs...)
+}
+
+// H2 creates a <h2> element
+func H2(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("h2")(specs...)
+}
+
+// H3 creates a <h3> element
+func H3(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("h3")(specs...)
+}
+
+// H4 creates a <h4> element
+func H4(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("h4")(specs...)
+}
+
+// H5 creates a <h5> element
+func H5(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("h5")(specs...)
+}
+
+// H6 creates a <h6> element
+func H6(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("h6")(specs...)
+}
+
+// Head creates a <head> element
+func Head(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("head")(specs...)
+}
+
+// Header creates a <header> element
+func Header(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("header")(specs...)
+}
+
+// Hgroup creates a <hgroup> element
+func Hgroup(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("hgroup")(specs...)
+}
+
+// Hr creates a <hr> element
+func Hr(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("hr")(specs...)
+}
+
+// HTML creates a <html> element
+func HTML(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("html")(specs...)
+}
+
+// I creates a <i> element
+func I(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("i")(specs...)
+}
+
+// IFrame creates a <iframe> element
+func IFrame(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("iframe")(specs...)
+}
+
+// Img creates a <img> element
+func Img(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("img")(specs...)
+}
+
+// Input creates a <input> element
+func Input(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("input")(specs...)
+}
+
+// Ins creates a <ins> element
+func Ins(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("ins")(specs...)
+}
+
+// Kbd creates a <kbd> element
+func Kbd(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("kbd")(specs...)
+}
+
+// Label creates a <label> element
+func Label(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("label")(specs...)
+}
+
+// Legend creates a <legend> element
+func Legend(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("legend")(specs...)
+}
+
+// Li creates a <li> element
+func Li(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("li")(specs...)
+}
+
+// Link creates a <link> element
+func Link(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("link")(specs...)
+}
+
+// Main creates a <main> element
+func Main(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("main")(specs...)
+}
+
+// Map creates a <map> element
+func Map(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("map")(specs...)
+}
+
+// Mark creates a <mark> element
+func Mark(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("mark")(specs...)
+}
+
+// Math creates a <math> element
+func Math(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("math")(specs...)
+}
+
+// Menu creates a <menu> element
+func Menu(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("menu")(specs...)
+}
+
+// Meta creates a <meta> element
+func Meta(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("meta")(specs...)
+}
+
+// Meter creates a <meter> element
+func Meter(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("meter")(specs...)
+}
+
+// Nav creates a <nav> element
+func Nav(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("nav")(specs...)
+}
+
+// NoScript creates a <noscript> element
+func NoScript(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("noscript")(specs...)
+}
+
+// Object creates a <object> element
+func Object(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("object")(specs...)
+}
+
+// Ol creates a <ol> element
+func Ol(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("ol")(specs...)
+}
+
+// OptGroup creates a <optgroup> element
+func OptGroup(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("optgroup")(specs...)
+}
+
+// Option creates a <option> element
+func Option(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("option")(specs...)
+}
+
+// Output creates a <output> element
+func Output(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("output")(specs...)
+}
+
+// P creates a <p> element
+func P(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("p")(specs...)
+}
+
+// Picture creates a <picture> element
+func Picture(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("picture")(specs...)
+}
+
+// Pre creates a <pre> element
+func Pre(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("pre")(specs...)
+}
+
+// Progress creates a <progress> element
+func Progress(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("progress")(specs...)
+}
+
+// Q creates a <q> element
+func Q(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("q")(specs...)
+}
+
+// Rp creates a <rp> element
+func Rp(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("rp")(specs...)
+}
+
+// Rt creates a <rt> element
+func Rt(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("rt")(specs...)
+}
+
+// Ruby creates a <ruby> element
+func Ruby(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("ruby")(specs...)
+}
+
+// S creates a <s> element
+func S(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("s")(specs...)
+}
+
+// Samp creates a <samp> element
+func Samp(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("samp")(specs...)
+}
+
+// Script creates a <script> element
+func Script(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("script")(specs...)
+}
+
+// Search creates a <search> element
+func Search(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("search")(specs...)
+}
+
+// Section creates a <section> element
+func Section(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("section")(specs...)
+}
+
+// Select creates a <select> element
+func Select(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("select")(specs...)
+}
+
+// Slot creates a <slot> element
+func Slot(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("slot")(specs...)
+}
+
+// Small creates a <small> element
+func Small(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("small")(specs...)
+}
+
+// Source creates a <source> element
+func Source(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("source")(specs...)
+}
+
+// Span creates a <span> element
+func Span(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("span")(specs...)
+}
+
+// Strong creates a <strong> element
+func Strong(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("strong")(specs...)
+}
+
+// Style creates a <style> element
+func Style(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("style")(specs...)
+}
+
+// Sub creates a <sub> element
+func Sub(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("sub")(specs...)
+}
+
+// Summary creates a <summary> element
+func Summary(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("summary")(specs...)
+}
+
+// Sup creates a <sup> element
+func Sup(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("sup")(specs...)
+}
+
+// SVG creates a <svg> element
+func SVG(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("svg")(specs...)
+}
+
+// Table creates a <table> element
+func Table(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("table")(specs...)
+}
+
+// TBody creates a <tbody> element
+func TBody(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("tbody")(specs...)
+}
+
+// Td creates a <td> element
+func Td(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("td")(specs...)
+}
+
+// Template creates a <template> element
+func Template(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("template")(specs...)
+}
+
+// TextArea creates a <textarea> element
+func TextArea(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("textarea")(specs...)
+}
+
+// TFoot creates a <tfoot> element
+func TFoot(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("tfoot")(specs...)
+}
+
+// Th creates a <th> element
+func Th(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("th")(specs...)
+}
+
+// THead creates a <thead> element
+func THead(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("thead")(specs...)
+}
+
+// Time creates a <time> element
+func Time(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("time")(specs...)
+}
+
+// Title creates a <title> element
+func Title(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("title")(specs...)
+}
+
+// Tr creates a <tr> element
+func Tr(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("tr")(specs...)
+}
+
+// Track creates a <track> element
+func Track(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("track")(specs...)
+}
+
+// U creates a <u> element
+func U(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("u")(specs...)
+}
+
+// Ul creates a <ul> element
+func Ul(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("ul")(specs...)
+}
+
+// Var creates a <var> element
+func Var(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("var")(specs...)
+}
+
+// Video creates a <video> element
+func Video(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("video")(specs...)
+}
+
+// Wbr creates a <wbr> element
+func Wbr(specs ...domui.Spec) *domui.Node {
+	return domui.Tag("wbr")(specs...)
+}
+
+// attributes
+
+// Accept sets the accept attribute
+func Accept(value string) domui.AttrSpec {
+	return domui.Attr("accept")(value)
+}
+
+// AcceptCharset sets the accept-charset attribute
+func AcceptCharset(value string) domui.AttrSpec {
+	return domui.Attr("accept-charset")(value)
+}
+
+// AccessKey sets the accesskey attribute
+func AccessKey(value string) domui.AttrSpec {
+	return domui.Attr("accesskey")(value)
+}
+
+// Action sets the action attribute
+func Action(value string) domui.AttrSpec {
+	return domui.Attr("action")(value)
+}
+
+// Allow sets the allow attribute
+func Allow(value string) domui.AttrSpec {
+	return domui.Attr("allow")(value)
+}
+
+// Alt sets the alt attribute
+func Alt(value string) domui.AttrSpec {
+	return domui.Attr("alt")(value)
+}
+
+// Async sets the async attribute
+func Async(value bool) domui.AttrSpec {
+	return domui.Attr("async")(value)
+}
+
+// AutoCapitalize sets the autocapitalize attribute
+func AutoCapitalize(value string) domui.AttrSpec {
+	return domui.Attr("autocapitalize")(value)
+}
+
+// AutoComplete sets the autocomplete attribute
+func AutoComplete(value string) domui.AttrSpec {
+	return domui.Attr("autocomplete")(value)
+}
+
+// AutoFocus sets the autofocus attribute
+func AutoFocus(value bool) domui.AttrSpec {
+	return domui.Attr("autofocus")(value)
+}
+
+// AutoPlay sets the autoplay attribute
+func AutoPlay(value bool) domui.AttrSpec {
+	return domui.Attr("autoplay")(value)
+}
+
+// Charset sets the charset attribute
+func Charset(value string) domui.AttrSpec {
+	return domui.Attr("charset")(value)
+}
+
+// Checked sets the checked attribute
+func Checked(value bool) domui.AttrSpec {
+	return domui.Attr("checked")(value)
+}
+
+// CiteAttr sets the cite attribute
+func CiteAttr(value string) domui.AttrSpec {
+	return domui.Attr("cite")(value)
+}
+
+// Cols sets the cols attribute
+func Cols(value int) domui.AttrSpec {
+	return domui.Attr("cols")(value)
+}
+
+// ColSpan sets the colspan attribute
+func ColSpan(value int) domui.AttrSpec {
+	return domui.Attr("colspan")(value)
+}
+
+// Content sets the content attribute
+func Content(value string) domui.AttrSpec {
+	return domui.Attr("content")(value)
+}
+
+// ContentEditable sets the contenteditable attribute
+func ContentEditable(value string) domui.AttrSpec {
+	return domui.Attr("contenteditable")(value)
+}
+
+// Controls sets the controls attribute
+func Controls(value bool) domui.AttrSpec {
+	return domui.Attr("controls")(value)
+}
+
+// Coords sets the coords attribute
+func Coords(value string) domui.AttrSpec {
+	return domui.Attr("coords")(value)
+}
+
+// CrossOrigin sets the crossorigin attribute
+func CrossOrigin(value string) domui.AttrSpec {
+	return domui.Attr("crossorigin")(value)
+}
+
+// DataAttr sets the data attribute
+func DataAttr(value string) domui.AttrSpec {
+	return domui.Attr("data")(value)
+}
+
+// DateTime sets the datetime attribute
+func DateTime(value string) domui.AttrSpec {
+	return domui.Attr("datetime")(value)
+}
+
+// Decoding sets the decoding attribute
+func Decoding(value string) domui.AttrSpec {
+	return domui.Attr("decoding")(value)
+}
+
+// Default sets the default attribute
+func Default(value bool) domui.AttrSpec {
+	return domui.Attr("default")(value)
+}
+
+// Defer sets the defer attribute
+func Defer(value bool) domui.AttrSpec {
+	return domui.Attr("defer")(value)
+}
+
+// Dir sets the dir attribute
+func Dir(value string) domui.AttrSpec {
+	return domui.Attr("dir")(value)
+}
+
+// DirName sets the dirname attribute
+func DirName(value string) domui.AttrSpec {
+	return domui.Attr("dirname")(value)
+}
+
+// Disabled sets the disabled attribute
+func Disabled(value bool) domui.AttrSpec {
+	return domui.Attr("disabled")(value)
+}
+
+// Download sets the download attribute
+func Download(value string) domui.AttrSpec {
+	return domui.Attr("download")(value)
+}
+
+// Draggable sets the draggable attribute
+func Draggable(value bool) domui.AttrSpec {
+	return domui.Attr("draggable")(strconv.FormatBool(value))
+}
+
+// EncType sets the enctype attribute
+func EncType(value string) domui.AttrSpec {
+	return domui.Attr("enctype")(value)
+}
+
+// EnterKeyHint sets the enterkeyhint attribute
+func EnterKeyHint(value string) domui.AttrSpec {
+	return domui.Attr("enterkeyhint")(value)
+}
+
+// FetchPriority sets the fetchpriority attribute
+func FetchPriority(value string) domui.AttrSpec {
+	return domui.Attr("fetchpriority")(value)
+}
+
+// For sets the for attribute
+func For(value string) domui.AttrSpec {
+	return domui.Attr("for")(value)
+}
+
+// FormAttr sets the form attribute
+func FormAttr(value string) domui.AttrSpec {
+	return domui.Attr("form")(value)
+}
+
+// FormAction sets the formaction attribute
+func FormAction(value string) domui.AttrSpec {
+	return domui.Attr("formaction")(value)
+}
+
+// FormEncType sets the formenctype attribute
+func FormEncType(value string) domui.AttrSpec {
+	return domui.Attr("formenctype")(value)
+}
+
+// FormMethod sets the formmethod attribute
+func FormMethod(value string) domui.AttrSpec {
+	return domui.Attr("formmethod")(value)
+}
+
+// FormNoValidate sets the formnovalidate attribute
+func FormNoValidate(value bool) domui.AttrSpec {
+	return domui.Attr("formnovalidate")(value)
+}
+
+// FormTarget sets the formtarget attribute
+func FormTarget(value string) domui.AttrSpec {
+	return domui.Attr("formtarget")(value)
+}
+
+// Headers sets the headers attribute
+func Headers(value string) domui.AttrSpec {
+	return domui.Attr("headers")(value)
+}
+
+// Height sets the height attribute
+func Height(value int) domui.AttrSpec {
+	return domui.Attr("height")(value)
+}
+
+// Hidden sets the hidden attribute
+func Hidden(value bool) domui.AttrSpec {
+	return domui.Attr("hidden")(value)
+}
+
+// High sets the high attribute
+func High(value float64) domui.AttrSpec {
+	return domui.Attr("high")(value)
+}
+
+// Href sets the href attribute
+func Href(value string) domui.AttrSpec {
+	return domui.Attr("href")(value)
+}
+
+// HrefLang sets the hreflang attribute
+func HrefLang(value string) domui.AttrSpec {
+	return domui.Attr("hreflang")(value)
+}
+
+// HTTPEquiv sets the http-equiv attribute
+func HTTPEquiv(value string) domui.AttrSpec {
+	return domui.Attr("http-equiv")(value)
+}
+
+// Inert sets the inert attribute
+func Inert(value bool) domui.AttrSpec {
+	return domui.Attr("inert")(value)
+}
+
+// InputMode sets the inputmode attribute
+func InputMode(value string) domui.AttrSpec {
+	return domui.Attr("inputmode")(value)
+}
+
+// Integrity sets the integrity attribute
+func Integrity(value string) domui.AttrSpec {
+	return domui.Attr("integrity")(value)
+}
+
+// IsMap sets the ismap attribute
+func IsMap(value bool) domui.AttrSpec {
+	return domui.Attr("ismap")(value)
+}
+
+// ItemProp sets the itemprop attribute
+func ItemProp(value string) domui.AttrSpec {
+	return domui.Attr("itemprop")(value)
+}
+
+// Kind sets the kind attribute
+func Kind(value string) domui.AttrSpec {
+	return domui.Attr("kind")(value)
+}
+
+// LabelAttr sets the label attribute
+func LabelAttr(value string) domui.AttrSpec {
+	return domui.Attr("label")(value)
+}
+
+// Lang sets the lang attribute
+func Lang(value string) domui.AttrSpec {
+	return domui.Attr("lang")(value)
+}
+
+// List sets the list attribute
+func List(value string) domui.AttrSpec {
+	return domui.Attr("list")(value)
+}
+
+// Loading sets the loading attribute
+func Loading(value string) domui.AttrSpec {
+	return domui.Attr("loading")(value)
+}
+
+// Loop sets the loop attribute
+func Loop(value bool) domui.AttrSpec {
+	return domui.Attr("loop")(value)
+}
+
+// Low sets the low attribute
+func Low(value float64) domui.AttrSpec {
+	return domui.Attr("low")(value)
+}
+
+// Max sets the max attribute
+func Max(value string) domui.AttrSpec {
+	return domui.Attr("max")(value)
+}
+
+// MaxLength sets the maxlength attribute
+func MaxLength(value int) domui.AttrSpec {
+	return domui.Attr("maxlength")(value)
+}
+
+// Media sets the media attribute
+func Media(value string) domui.AttrSpec {
+	return domui.Attr("media")(value)
+}
+
+// Method sets the method attribute
+func Method(value string) domui.AttrSpec {
+	return domui.Attr("method")(value)
+}
+
+// Min sets the min attribute
+func Min(value string) domui.AttrSpec {
+	return domui.Attr("min")(value)
+}
+
+// MinLength sets the minlength attribute
+func MinLength(value int) domui.AttrSpec {
+	return domui.Attr("minlength")(value)
+}
+
+// Multiple sets the multiple attribute
+func Multiple(value bool) domui.AttrSpec {
+	return domui.Attr("multiple")(value)
+}
+
+// Muted sets the muted attribute
+func Muted(value bool) domui.AttrSpec {
+	return domui.Attr("muted")(value)
+}
+
+// Name sets the name attribute
+func Name(value string) domui.AttrSpec {
+	return domui.Attr("name")(value)
+}
+
+// NoModule sets the nomodule attribute
+func NoModule(value bool) domui.AttrSpec {
+	return domui.Attr("nomodule")(value)
+}
+
+// NoValidate sets the novalidate attribute
+func NoValidate(value bool) domui.AttrSpec {
+	return domui.Attr("novalidate")(value)
+}
+
+// Open sets the open attribute
+func Open(value bool) domui.AttrSpec {
+	return domui.Attr("open")(value)
+}
+
+// Optimum sets the optimum attribute
+func Optimum(value float64) domui.AttrSpec {
+	return domui.Attr("optimum")(value)
+}
+
+// Pattern sets the pattern attribute
+func Pattern(value string) domui.AttrSpec {
+	return domui.Attr("pattern")(value)
+}
+
+// Ping sets the ping attribute
+func Ping(value string) domui.AttrSpec {
+	return domui.Attr("ping")(value)
+}
+
+// Placeholder sets the placeholder attribute
+func Placeholder(value string) domui.AttrSpec {
+	return domui.Attr("placeholder")(value)
+}
+
+// PlaysInline sets the playsinline attribute
+func PlaysInline(value bool) domui.AttrSpec {
+	return domui.Attr("playsinline")(value)
+}
+
+// Popover sets the popover attribute
+func Popover(value string) domui.AttrSpec {
+	return domui.Attr("popover")(value)
+}
+
+// PopoverTarget sets the popovertarget attribute
+func PopoverTarget(value string) domui.AttrSpec {
+	return domui.Attr("popovertarget")(value)
+}
+
+// Poster sets the poster attribute
+func Poster(value string) domui.AttrSpec {
+	return domui.Attr("poster")(value)
+}
+
+// Preload sets the preload attribute
+func Preload(value string) domui.AttrSpec {
+	return domui.Attr("preload")(value)
+}
+
+// ReadOnly sets the readonly attribute
+func ReadOnly(value bool) domui.AttrSpec {
+	return domui.Attr("readonly")(value)
+}
+
+// ReferrerPolicy sets the referrerpolicy attribute
+func ReferrerPolicy(value string) domui.AttrSpec {
+	return domui.Attr("referrerpolicy")(value)
+}
+
+// Rel sets the rel attribute
+func Rel(value string) domui.AttrSpec {
+	return domui.Attr("rel")(value)
+}
+
+// Required sets the required attribute
+func Required(value bool) domui.AttrSpec {
+	return domui.Attr("required")(value)
+}
+
+// Reversed sets the reversed attribute
+func Reversed(value bool) domui.AttrSpec {
+	return domui.Attr("reversed")(value)
+}
+
+// Role sets the role attribute
+func Role(value string) domui.AttrSpec {
+	return domui.Attr("role")(value)
+}
+
+// Rows sets the rows attribute
+func Rows(value int) domui.AttrSpec {
+	return domui.Attr("rows")(value)
+}
+
+// RowSpan sets the rowspan attribute
+func RowSpan(value int) domui.AttrSpec {
+	return domui.Attr("rowspan")(value)
+}
+
+// Sandbox sets the sandbox attribute
+func Sandbox(value string) domui.AttrSpec {
+	return domui.Attr("sandbox")(value)
+}
+
+// Scope sets the scope attribute
+func Scope(value string) domui.AttrSpec {
+	return domui.Attr("scope")(value)
+}
+
+// Selected sets the selected attribute
+func Selected(value bool) domui.AttrSpec {
+	return domui.Attr("selected")(value)
+}
+
+// Shape sets the shape attribute
+func Shape(value string) domui.AttrSpec {
+	return domui.Attr("shape")(value)
+}
+
+// Size sets the size attribute
+func Size(value int) domui.AttrSpec {
+	return domui.Attr("size")(value)
+}
+
+// Sizes sets the sizes attribute
+func Sizes(value string) domui.AttrSpec {
+	return domui.Attr("sizes")(value)
+}
+
+// SlotAttr sets the slot attribute
+func SlotAttr(value string) domui.AttrSpec {
+	return domui.Attr("slot")(value)
+}
+
+// SpanAttr sets the span attribute
+func SpanAttr(value int) domui.AttrSpec {
+	return domui.Attr("span")(value)
+}
+
+// SpellCheck sets the spellcheck attribute
+func SpellCheck(value bool) domui.AttrSpec {
+	return domui.Attr("spellcheck")(strconv.FormatBool(value))
+}
+
+// Src sets the src attribute
+func Src(value string) domui.AttrSpec {
+	return domui.Attr("src")(value)
+}
+
+// SrcDoc sets the srcdoc attribute
+func SrcDoc(value string) domui.AttrSpec {
+	return domui.Attr("srcdoc")(value)
+}
+
+// SrcLang sets the srclang attribute
+func SrcLang(value string) domui.AttrSpec {
+	return domui.Attr("srclang")(value)
+}
+
+// SrcSet sets the srcset attribute
+func SrcSet(value string) domui.AttrSpec {
+	return domui.Attr("srcset")(value)
+}
+
+// Start sets the start attribute
+func Start(value int) domui.AttrSpec {
+	return domui.Attr("start")(value)
+}
+
+// Step sets the step attribute
+func Step(value string) domui.AttrSpec {
+	return domui.Attr("step")(value)
+}
+
+// TabIndex sets the tabindex attribute
+func TabIndex(value int) domui.AttrSpec {
+	return domui.Attr("tabindex")(value)
+}
+
+// Target sets the target attribute
+func Target(value string) domui.AttrSpec {
+	return domui.Attr("target")(value)
+}
+
+// TitleAttr sets the title attribute
+func TitleAttr(value string) domui.AttrSpec {
+	return domui.Attr("title")(value)
+}
+
+// Translate sets the translate attribute
+func Translate(value string) domui.AttrSpec {
+	return domui.Attr("translate")(value)
+}
+
+// Type sets the type attribute
+func Type(value string) domui.AttrSpec {
+	return domui.Attr("type")(value)
+}
+
+// UseMap sets the usemap attribute
+func UseMap(value string) domui.AttrSpec {
+	return domui.Attr("usemap")(value)
+}
+
+// Value sets the value attribute
+func Value(value string) domui.AttrSpec {
+	return domui.Attr("value")(value)
+}
+
+// Width sets the width attribute
+func Width(value int) domui.AttrSpec {
+	return domui.Attr("width")(value)
+}
+
+// Wrap sets the wrap attribute
+func Wrap(value string) domui.AttrSpec {
+	return domui.Attr("wrap")(value)
+}
+
+// css properties
+
+// AlignContent sets the align-content css property
+func AlignContent(format string, args ...any) domui.StyleSpec {
+	return domui.Style("align-content")(format, args...)
+}
+
+// AlignItems sets the align-items css property
+func AlignItems(format string, args ...any) domui.StyleSpec {
+	return domui.Style("align-items")(format, args...)
+}
+
+// AlignSelf sets the align-self css property
+func AlignSelf(format string, args ...any) domui.StyleSpec {
+	return domui.Style("align-self")(format, args...)
+}
+
+// Animation sets the animation css property
+func Animation(format string, args ...any) domui.StyleSpec {
+	return domui.Style("animation")(format, args...)
+}
+
+// Background sets the background css property
+func Background(format string, args ...any) domui.StyleSpec {
+	return domui.Style("background")(format, args...)
+}
+
+// BackgroundColor sets the background-color css property
+func BackgroundColor(format string, args ...any) domui.StyleSpec {
+	return domui.Style("background-color")(format, args...)
+}
+
+// BackgroundImage sets the background-image css property
+func BackgroundImage(format string, args ...any) domui.StyleSpec {
+	return domui.Style("background-image")(format, args...)
+}
+
+// BackgroundPosition sets the background-position css property
+func BackgroundPosition(format string, args ...any) domui.StyleSpec {
+	return domui.Style("background-position")(format, args...)
+}
+
+// BackgroundRepeat sets the background-repeat css property
+func BackgroundRepeat(format string, args ...any) domui.StyleSpec {
+	return domui.Style("background-repeat")(format, args...)
+}
+
+// BackgroundSize sets the background-size css property
+func BackgroundSize(format string, args ...any) domui.StyleSpec {
+	return domui.Style("background-size")(format, args...)
+}
+
+// Border sets the border css property
+func Border(format string, args ...any) domui.StyleSpec {
+	return domui.Style("border")(format, args...)
+}
+
+// BorderBottom sets the border-bottom css property
+func BorderBottom(format string, args ...any) domui.StyleSpec {
+	return domui.Style("border-bottom")(format, args...)
+}
+
+// BorderCollapse sets the border-collapse css property
+func BorderCollapse(format string, args ...any) domui.StyleSpec {
+	return domui.Style("border-collapse")(format, args...)
+}
+
+// BorderColor sets the border-color css property
+func BorderColor(format string, args ...any) domui.StyleSpec {
+	return domui.Style("border-color")(format, args...)
+}
+
+// BorderLeft sets the border-left css property
+func BorderLeft(format string, args ...any) domui.StyleSpec {
+	return domui.Style("border-left")(format, args...)
+}
+
+// BorderRadius sets the border-radius css property
+func BorderRadius(format string, args ...any) domui.StyleSpec {
+	return domui.Style("border-radius")(format, args...)
+}
+
+// BorderRight sets the border-right css property
+func BorderRight(format string, args ...any) domui.StyleSpec {
+	return domui.Style("border-right")(format, args...)
+}
+
+// BorderStyle sets the border-style css property
+func BorderStyle(format string, args ...any) domui.StyleSpec {
+	return domui.Style("border-style")(format, args...)
+}
+
+// BorderTop sets the border-top css property
+func BorderTop(format string, args ...any) domui.StyleSpec {
+	return domui.Style("border-top")(format, args...)
+}
+
+// BorderWidth sets the border-width css property
+func BorderWidth(format string, args ...any) domui.StyleSpec {
+	return domui.Style("border-width")(format, args...)
+}
+
+// Bottom sets the bottom css property
+func Bottom(format string, args ...any) domui.StyleSpec {
+	return domui.Style("bottom")(format, args...)
+}
+
+// BoxShadow sets the box-shadow css property
+func BoxShadow(format string, args ...any) domui.StyleSpec {
+	return domui.Style("box-shadow")(format, args...)
+}
+
+// BoxSizing sets the box-sizing css property
+func BoxSizing(format string, args ...any) domui.StyleSpec {
+	return domui.Style("box-sizing")(format, args...)
+}
+
+// Clear sets the clear css property
+func Clear(format string, args ...any) domui.StyleSpec {
+	return domui.Style("clear")(format, args...)
+}
+
+// Color sets the color css property
+func Color(format string, args ...any) domui.StyleSpec {
+	return domui.Style("color")(format, args...)
+}
+
+// ColumnGap sets the column-gap css property
+func ColumnGap(format string, args ...any) domui.StyleSpec {
+	return domui.Style("column-gap")(format, args...)
+}
+
+// ContentStyle sets the content css property
+func ContentStyle(format string, args ...any) domui.StyleSpec {
+	return domui.Style("content")(format, args...)
+}
+
+// Cursor sets the cursor css property
+func Cursor(format string, args ...any) domui.StyleSpec {
+	return domui.Style("cursor")(format, args...)
+}
+
+// Display sets the display css property
+func Display(format string, args ...any) domui.StyleSpec {
+	return domui.Style("display")(format, args...)
+}
+
+// Flex sets the flex css property
+func Flex(format string, args ...any) domui.StyleSpec {
+	return domui.Style("flex")(format, args...)
+}
+
+// FlexBasis sets the flex-basis css property
+func FlexBasis(format string, args ...any) domui.StyleSpec {
+	return domui.Style("flex-basis")(format, args...)
+}
+
+// FlexDirection sets the flex-direction css property
+func FlexDirection(format string, args ...any) domui.StyleSpec {
+	return domui.Style("flex-direction")(format, args...)
+}
+
+// FlexGrow sets the flex-grow css property
+func FlexGrow(format string, args ...any) domui.StyleSpec {
+	return domui.Style("flex-grow")(format, args...)
+}
+
+// FlexShrink sets the flex-shrink css property
+func FlexShrink(format string, args ...any) domui.StyleSpec {
+	return domui.Style("flex-shrink")(format, args...)
+}
+
+// FlexWrap sets the flex-wrap css property
+func FlexWrap(format string, args ...any) domui.StyleSpec {
+	return domui.Style("flex-wrap")(format, args...)
+}
+
+// Float sets the float css property
+func Float(format string, args ...any) domui.StyleSpec {
+	return domui.Style("float")(format, args...)
+}
+
+// Font sets the font css property
+func Font(format string, args ...any) domui.StyleSpec {
+	return domui.Style("font")(format, args...)
+}
+
+// FontFamily sets the font-family css property
+func FontFamily(format string, args ...any) domui.StyleSpec {
+	return domui.Style("font-family")(format, args...)
+}
+
+// FontSize sets the font-size css property
+func FontSize(format string, args ...any) domui.StyleSpec {
+	return domui.Style("font-size")(format, args...)
+}
+
+// FontStyle sets the font-style css property
+func FontStyle(format string, args ...any) domui.StyleSpec {
+	return domui.Style("font-style")(format, args...)
+}
+
+// FontWeight sets the font-weight css property
+func FontWeight(format string, args ...any) domui.StyleSpec {
+	return domui.Style("font-weight")(format, args...)
+}
+
+// Gap sets the gap css property
+func Gap(format string, args ...any) domui.StyleSpec {
+	return domui.Style("gap")(format, args...)
+}
+
+// GridArea sets the grid-area css property
+func GridArea(format string, args ...any) domui.StyleSpec {
+	return domui.Style("grid-area")(format, args...)
+}
+
+// GridColumn sets the grid-column css property
+func GridColumn(format string, args ...any) domui.StyleSpec {
+	return domui.Style("grid-column")(format, args...)
+}
+
+// GridRow sets the grid-row css property
+func GridRow(format string, args ...any) domui.StyleSpec {
+	return domui.Style("grid-row")(format, args...)
+}
+
+// GridTemplateAreas sets the grid-template-areas css property
+func GridTemplateAreas(format string, args ...any) domui.StyleSpec {
+	return domui.Style("grid-template-areas")(format, args...)
+}
+
+// GridTemplateColumns sets the grid-template-columns css property
+func GridTemplateColumns(format string, args ...any) domui.StyleSpec {
+	return domui.Style("grid-template-columns")(format, args...)
+}
+
+// GridTemplateRows sets the grid-template-rows css property
+func GridTemplateRows(format string, args ...any) domui.StyleSpec {
+	return domui.Style("grid-template-rows")(format, args...)
+}
+
+// HeightStyle sets the height css property
+func HeightStyle(format string, args ...any) domui.StyleSpec {
+	return domui.Style("height")(format, args...)
+}
+
+// JustifyContent sets the justify-content css property
+func JustifyContent(format string, args ...any) domui.StyleSpec {
+	return domui.Style("justify-content")(format, args...)
+}
+
+// JustifyItems sets the justify-items css property
+func JustifyItems(format string, args ...any) domui.StyleSpec {
+	return domui.Style("justify-items")(format, args...)
+}
+
+// Left sets the left css property
+func Left(format string, args ...any) domui.StyleSpec {
+	return domui.Style("left")(format, args...)
+}
+
+// LetterSpacing sets the letter-spacing css property
+func LetterSpacing(format string, args ...any) domui.StyleSpec {
+	return domui.Style("letter-spacing")(format, args...)
+}
+
+// LineHeight sets the line-height css property
+func LineHeight(format string, args ...any) domui.StyleSpec {
+	return domui.Style("line-height")(format, args...)
+}
+
+// ListStyle sets the list-style css property
+func ListStyle(format string, args ...any) domui.StyleSpec {
+	return domui.Style("list-style")(format, args...)
+}
+
+// Margin sets the margin css property
+func Margin(format string, args ...any) domui.StyleSpec {
+	return domui.Style("margin")(format, args...)
+}
+
+// MarginBottom sets the margin-bottom css property
+func MarginBottom(format string, args ...any) domui.StyleSpec {
+	return domui.Style("margin-bottom")(format, args...)
+}
+
+// MarginLeft sets the margin-left css property
+func MarginLeft(format string, args ...any) domui.StyleSpec {
+	return domui.Style("margin-left")(format, args...)
+}
+
+// MarginRight sets the margin-right css property
+func MarginRight(format string, args ...any) domui.StyleSpec {
+	return domui.Style("margin-right")(format, args...)
+}
+
+// MarginTop sets the margin-top css property
+func MarginTop(format string, args ...any) domui.StyleSpec {
+	return domui.Style("margin-top")(format, args...)
+}
+
+// MaxHeight sets the max-height css property
+func MaxHeight(format string, args ...any) domui.StyleSpec {
+	return domui.Style("max-height")(format, args...)
+}
+
+// MaxWidth sets the max-width css property
+func MaxWidth(format string, args ...any) domui.StyleSpec {
+	return domui.Style("max-width")(format, args...)
+}
+
+// MinHeight sets the min-height css property
+func MinHeight(format string, args ...any) domui.StyleSpec {
+	return domui.Style("min-height")(format, args...)
+}
+
+// MinWidth sets the min-width css property
+func MinWidth(format string, args ...any) domui.StyleSpec {
+	return domui.Style("min-width")(format, args...)
+}
+
+// ObjectFit sets the object-fit css property
+func ObjectFit(format string, args ...any) domui.StyleSpec {
+	return domui.Style("object-fit")(format, args...)
+}
+
+// Opacity sets the opacity css property
+func Opacity(format string, args ...any) domui.StyleSpec {
+	return domui.Style("opacity")(format, args...)
+}
+
+// Order sets the order css property
+func Order(format string, args ...any) domui.StyleSpec {
+	return domui.Style("order")(format, args...)
+}
+
+// Outline sets the outline css property
+func Outline(format string, args ...any) domui.StyleSpec {
+	return domui.Style("outline")(format, args...)
+}
+
+// Overflow sets the overflow css property
+func Overflow(format string, args ...any) domui.StyleSpec {
+	return domui.Style("overflow")(format, args...)
+}
+
+// OverflowX sets the overflow-x css property
+func OverflowX(format string, args ...any) domui.StyleSpec {
+	return domui.Style("overflow-x")(format, args...)
+}
+
+// OverflowY sets the overflow-y css property
+func OverflowY(format string, args ...any) domui.StyleSpec {
+	return domui.Style("overflow-y")(format, args...)
+}
+
+// Padding sets the padding css property
+func Padding(format string, args ...any) domui.StyleSpec {
+	return domui.Style("padding")(format, args...)
+}
+
+// PaddingBottom sets the padding-bottom css property
+func PaddingBottom(format string, args ...any) domui.StyleSpec {
+	return domui.Style("padding-bottom")(format, args...)
+}
+
+// PaddingLeft sets the padding-left css property
+func PaddingLeft(format string, args ...any) domui.StyleSpec {
+	return domui.Style("padding-left")(format, args...)
+}
+
+// PaddingRight sets the padding-right css property
+func PaddingRight(format string, args ...any) domui.StyleSpec {
+	return domui.Style("padding-right")(format, args...)
+}
+
+// PaddingTop sets the padding-top css property
+func PaddingTop(format string, args ...any) domui.StyleSpec {
+	return domui.Style("padding-top")(format, args...)
+}
+
+// PointerEvents sets the pointer-events css property
+func PointerEvents(format string, args ...any) domui.StyleSpec {
+	return domui.Style("pointer-events")(format, args...)
+}
+
+// Position sets the position css property
+func Position(format string, args ...any) domui.StyleSpec {
+	return domui.Style("position")(format, args...)
+}
+
+// Right sets the right css property
+func Right(format string, args ...any) domui.StyleSpec {
+	return domui.Style("right")(format, args...)
+}
+
+// RowGap sets the row-gap css property
+func RowGap(format string, args ...any) domui.StyleSpec {
+	return domui.Style("row-gap")(format, args...)
+}
+
+// TextAlign sets the text-align css property
+func TextAlign(format string, args ...any) domui.StyleSpec {
+	return domui.Style("text-align")(format, args...)
+}
+
+// TextDecoration sets the text-decoration css property
+func TextDecoration(format string, args ...any) domui.StyleSpec {
+	return domui.Style("text-decoration")(format, args...)
+}
+
+// TextOverflow sets the text-overflow css property
+func TextOverflow(format string, args ...any) domui.StyleSpec {
+	return domui.Style("text-overflow")(format, args...)
+}
+
+// TextTransform sets the text-transform css property
+func TextTransform(format string, args ...any) domui.StyleSpec {
+	return domui.Style("text-transform")(format, args...)
+}
+
+// Top sets the top css property
+func Top(format string, args ...any) domui.StyleSpec {
+	return domui.Style("top")(format, args...)
+}
+
+// Transform sets the transform css property
+func Transform(format string, args ...any) domui.StyleSpec {
+	return domui.Style("transform")(format, args...)
+}
+
+// Transition sets the transition css property
+func Transition(format string, args ...any) domui.StyleSpec {
+	return domui.Style("transition")(format, args...)
+}
+
+// UserSelect sets the user-select css property
+func UserSelect(format string, args ...any) domui.StyleSpec {
+	return domui.Style("user-select")(format, args...)
+}
+
+// VerticalAlign sets the vertical-align css property
+func VerticalAlign(format string, args ...any) domui.StyleSpec {
+	return domui.Style("vertical-align")(format, args...)
+}
+
+// Visibility sets the visibility css property
+func Visibility(format string, args ...any) domui.StyleSpec {
+	return domui.Style("visibility")(format, args...)
+}
+
+// WhiteSpace sets the white-space css property
+func WhiteSpace(format string, args ...any) domui.StyleSpec {
+	return domui.Style("white-space")(format, args...)
+}
+
+// WidthStyle sets the width css property
+func WidthStyle(format string, args ...any) domui.StyleSpec {
+	return domui.Style("width")(format, args...)
+}
+
+// WordBreak sets the word-break css property
+func WordBreak(format string, args ...any) domui.StyleSpec {
+	return domui.Style("word-break")(format, args...)
+}
+
+// ZIndex sets the z-index css property
+func ZIndex(format string, args ...any) domui.StyleSpec {
+	return domui.Style("z-index")(format, args...)
+}
+
+// events
+
+// OnAbort handles the abort event
+func OnAbort(fn any) domui.EventSpec {
+	return domui.On("abort")(fn)
+}
+
+// OnAnimationEnd handles the animationend event
+func OnAnimationEnd(fn any) domui.EventSpec {
+	return domui.On("animationend")(fn)
+}
+
+// OnAnimationIteration handles the animationiteration event
+func OnAnimationIteration(fn any) domui.EventSpec {
+	return domui.On("animationiteration")(fn)
+}
+
+// OnAnimationStart handles the animationstart event
+func OnAnimationStart(fn any) domui.EventSpec {
+	return domui.On("animationstart")(fn)
+}
+
+// OnAuxClick handles the auxclick event
+func OnAuxClick(fn any) domui.EventSpec {
+	return domui.On("auxclick")(fn)
+}
+
+// OnBeforeInput handles the beforeinput event
+func OnBeforeInput(fn any) domui.EventSpec {
+	return domui.On("beforeinput")(fn)
+}
+
+// OnBlur handles the blur event
+func OnBlur(fn any) domui.EventSpec {
+	return domui.On("blur")(fn)
+}
+
+// OnCancel handles the cancel event
+func OnCancel(fn any) domui.EventSpec {
+	return domui.On("cancel")(fn)
+}
+
+// OnCanPlay handles the canplay event
+func OnCanPlay(fn any) domui.EventSpec {
+	return domui.On("canplay")(fn)
+}
+
+// OnCanPlayThrough handles the canplaythrough event
+func OnCanPlayThrough(fn any) domui.EventSpec {
+	return domui.On("canplaythrough")(fn)
+}
+
+// OnChange handles the change event
+func OnChange(fn any) domui.EventSpec {
+	return domui.On("change")(fn)
+}
+
+// OnClick handles the click event
+func OnClick(fn any) domui.EventSpec {
+	return domui.On("click")(fn)
+}
+
+// OnClose handles the close event
+func OnClose(fn any) domui.EventSpec {
+	return domui.On("close")(fn)
+}
+
+// OnContextMenu handles the contextmenu event
+func OnContextMenu(fn any) domui.EventSpec {
+	return domui.On("contextmenu")(fn)
+}
+
+// OnCopy handles the copy event
+func OnCopy(fn any) domui.EventSpec {
+	return domui.On("copy")(fn)
+}
+
+// OnCut handles the cut event
+func OnCut(fn any) domui.EventSpec {
+	return domui.On("cut")(fn)
+}
+
+// OnDblClick handles the dblclick event
+func OnDblClick(fn any) domui.EventSpec {
+	return domui.On("dblclick")(fn)
+}
+
+// OnDrag handles the drag event
+func OnDrag(fn any) domui.EventSpec {
+	return domui.On("drag")(fn)
+}
+
+// OnDragEnd handles the dragend event
+func OnDragEnd(fn any) domui.EventSpec {
+	return domui.On("dragend")(fn)
+}
+
+// OnDragEnter handles the dragenter event
+func OnDragEnter(fn any) domui.EventSpec {
+	return domui.On("dragenter")(fn)
+}
+
+// OnDragLeave handles the dragleave event
+func OnDragLeave(fn any) domui.EventSpec {
+	return domui.On("dragleave")(fn)
+}
+
+// OnDragOver handles the dragover event
+func OnDragOver(fn any) domui.EventSpec {
+	return domui.On("dragover")(fn)
+}
+
+// OnDragStart handles the dragstart event
+func OnDragStart(fn any) domui.EventSpec {
+	return domui.On("dragstart")(fn)
+}
+
+// OnDrop handles the drop event
+func OnDrop(fn any) domui.EventSpec {
+	return domui.On("drop")(fn)
+}
+
+// OnDurationChange handles the durationchange event
+func OnDurationChange(fn any) domui.EventSpec {
+	return domui.On("durationchange")(fn)
+}
+
+// OnEnded handles the ended event
+func OnEnded(fn any) domui.EventSpec {
+	return domui.On("ended")(fn)
+}
+
+// OnError handles the error event
+func OnError(fn any) domui.EventSpec {
+	return domui.On("error")(fn)
+}
+
+// OnFocus handles the focus event
+func OnFocus(fn any) domui.EventSpec {
+	return domui.On("focus")(fn)
+}
+
+// OnFocusIn handles the focusin event
+func OnFocusIn(fn any) domui.EventSpec {
+	return domui.On("focusin")(fn)
+}
+
+// OnFocusOut handles the focusout event
+func OnFocusOut(fn any) domui.EventSpec {
+	return domui.On("focusout")(fn)
+}
+
+// OnFullscreenChange handles the fullscreenchange event
+func OnFullscreenChange(fn any) domui.EventSpec {
+	return domui.On("fullscreenchange")(fn)
+}
+
+// OnInput handles the input event
+func OnInput(fn any) domui.EventSpec {
+	return domui.On("input")(fn)
+}
+
+// OnInvalid handles the invalid event
+func OnInvalid(fn any) domui.EventSpec {
+	return domui.On("invalid")(fn)
+}
+
+// OnKeyDown handles the keydown event
+func OnKeyDown(fn any) domui.EventSpec {
+	return domui.On("keydown")(fn)
+}
+
+// OnKeyUp handles the keyup event
+func OnKeyUp(fn any) domui.EventSpec {
+	return domui.On("keyup")(fn)
+}
+
+// OnLoad handles the load event
+func OnLoad(fn any) domui.EventSpec {
+	return domui.On("load")(fn)
+}
+
+// OnLoadedData handles the loadeddata event
+func OnLoadedData(fn any) domui.EventSpec {
+	return domui.On("loadeddata")(fn)
+}
+
+// OnLoadedMetadata handles the loadedmetadata event
+func OnLoadedMetadata(fn any) domui.EventSpec {
+	return domui.On("loadedmetadata")(fn)
+}
+
+// OnLoadStart handles the loadstart event
+func OnLoadStart(fn any) domui.EventSpec {
+	return domui.On("loadstart")(fn)
+}
+
+// OnMouseDown handles the mousedown event
+func OnMouseDown(fn any) domui.EventSpec {
+	return domui.On("mousedown")(fn)
+}
+
+// OnMouseEnter handles the mouseenter event
+func OnMouseEnter(fn any) domui.EventSpec {
+	return domui.On("mouseenter")(fn)
+}
+
+// OnMouseLeave handles the mouseleave event
+func OnMouseLeave(fn any) domui.EventSpec {
+	return domui.On("mouseleave")(fn)
+}
+
+// OnMouseMove handles the mousemove event
+func OnMouseMove(fn any) domui.EventSpec {
+	return domui.On("mousemove")(fn)
+}
+
+// OnMouseOut handles the mouseout event
+func OnMouseOut(fn any) domui.EventSpec {
+	return domui.On("mouseout")(fn)
+}
+
+// OnMouseOver handles the mouseover event
+func OnMouseOver(fn any) domui.EventSpec {
+	return domui.On("mouseover")(fn)
+}
+
+// OnMouseUp handles the mouseup event
+func OnMouseUp(fn any) domui.EventSpec {
+	return domui.On("mouseup")(fn)
+}
+
+// OnPaste handles the paste event
+func OnPaste(fn any) domui.EventSpec {
+	return domui.On("paste")(fn)
+}
+
+// OnPause handles the pause event
+func OnPause(fn any) domui.EventSpec {
+	return domui.On("pause")(fn)
+}
+
+// OnPlay handles the play event
+func OnPlay(fn any) domui.EventSpec {
+	return domui.On("play")(fn)
+}
+
+// OnPlaying handles the playing event
+func OnPlaying(fn any) domui.EventSpec {
+	return domui.On("playing")(fn)
+}
+
+// OnPointerCancel handles the pointercancel event
+func OnPointerCancel(fn any) domui.EventSpec {
+	return domui.On("pointercancel")(fn)
+}
+
+// OnPointerDown handles the pointerdown event
+func OnPointerDown(fn any) domui.EventSpec {
+	return domui.On("pointerdown")(fn)
+}
+
+// OnPointerEnter handles the pointerenter event
+func OnPointerEnter(fn any) domui.EventSpec {
+	return domui.On("pointerenter")(fn)
+}
+
+// OnPointerLeave handles the pointerleave event
+func OnPointerLeave(fn any) domui.EventSpec {
+	return domui.On("pointerleave")(fn)
+}
+
+// OnPointerMove handles the pointermove event
+func OnPointerMove(fn any) domui.EventSpec {
+	return domui.On("pointermove")(fn)
+}
+
+// OnPointerOut handles the pointerout event
+func OnPointerOut(fn any) domui.EventSpec {
+	return domui.On("pointerout")(fn)
+}
+
+// OnPointerOver handles the pointerover event
+func OnPointerOver(fn any) domui.EventSpec {
+	return domui.On("pointerover")(fn)
+}
+
+// OnPointerUp handles the pointerup event
+func OnPointerUp(fn any) domui.EventSpec {
+	return domui.On("pointerup")(fn)
+}
+
+// OnProgress handles the progress event
+func OnProgress(fn any) domui.EventSpec {
+	return domui.On("progress")(fn)
+}
+
+// OnRateChange handles the ratechange event
+func OnRateChange(fn any) domui.EventSpec {
+	return domui.On("ratechange")(fn)
+}
+
+// OnReset handles the reset event
+func OnReset(fn any) domui.EventSpec {
+	return domui.On("reset")(fn)
+}
+
+// OnResize handles the resize event
+func OnResize(fn any) domui.EventSpec {
+	return domui.On("resize")(fn)
+}
+
+// OnScroll handles the scroll event
+func OnScroll(fn any) domui.EventSpec {
+	return domui.On("scroll")(fn)
+}
+
+// OnScrollEnd handles the scrollend event
+func OnScrollEnd(fn any) domui.EventSpec {
+	return domui.On("scrollend")(fn)
+}
+
+// OnSeeked handles the seeked event
+func OnSeeked(fn any) domui.EventSpec {
+	return domui.On("seeked")(fn)
+}
+
+// OnSeeking handles the seeking event
+func OnSeeking(fn any) domui.EventSpec {
+	return domui.On("seeking")(fn)
+}
+
+// OnSelect handles the select event
+func OnSelect(fn any) domui.EventSpec {
+	return domui.On("select")(fn)
+}
+
+// OnSelectionChange handles the selectionchange event
+func OnSelectionChange(fn any) domui.EventSpec {
+	return domui.On("selectionchange")(fn)
+}
+
+// OnStalled handles the stalled event
+func OnStalled(fn any) domui.EventSpec {
+	return domui.On("stalled")(fn)
+}
+
+// OnSubmit handles the submit event
+func OnSubmit(fn any) domui.EventSpec {
+	return domui.On("submit")(fn)
+}
+
+// OnSuspend handles the suspend event
+func OnSuspend(fn any) domui.EventSpec {
+	return domui.On("suspend")(fn)
+}
+
+// OnTimeUpdate handles the timeupdate event
+func OnTimeUpdate(fn any) domui.EventSpec {
+	return domui.On("timeupdate")(fn)
+}
+
+// OnToggle handles the toggle event
+func OnToggle(fn any) domui.EventSpec {
+	return domui.On("toggle")(fn)
+}
+
+// OnTouchCancel handles the touchcancel event
+func OnTouchCancel(fn any) domui.EventSpec {
+	return domui.On("touchcancel")(fn)
+}
+
+// OnTouchEnd handles the touchend event
+func OnTouchEnd(fn any) domui.EventSpec {
+	return domui.On("touchend")(fn)
+}
+
+// OnTouchMove handles the touchmove event
+func OnTouchMove(fn any) domui.EventSpec {
+	return domui.On("touchmove")(fn)
+}
+
+// OnTouchStart handles the touchstart event
+func OnTouchStart(fn any) domui.EventSpec {
+	return domui.On("touchstart")(fn)
+}
+
+// OnTransitionEnd handles the transitionend event
+func OnTransitionEnd(fn any) domui.EventSpec {
+	return domui.On("transitionend")(fn)
+}
+
+// OnVolumeChange handles the volumechange event
+func OnVolumeChange(fn any) domui.EventSpec {
+	return domui.On("volumechange")(fn)
+}
+
+// OnWaiting handles the waiting event
+func OnWaiting(fn any) domui.EventSpec {
+	return domui.On("waiting")(fn)
+}
+
+// OnWheel handles the wheel event
+func OnWheel(fn any) domui.EventSpec {
+	return domui.On("wheel")(fn)
+}
@@ -0,0 +1,54 @@
+package html
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/reusee/domui"
+)
+
+func TestConstructors(t *testing.T) {
+	node := Div(
+		A(Href("/foo"), TabIndex(1), Draggable(false), domui.Text("foo")),
+		Input(Type("checkbox"), Disabled(true), Checked(false)),
+		Label(TitleAttr("bar"), For("baz")),
+		FontSize("%dpx", 12),
+		WidthStyle("50%%"),
+		OnClick(func() {}),
+	)
+	if html := node.HTML(); html != `<div style="font-size: 12px; width: 50%;"><a draggable="false" href="/foo" tabindex="1">foo</a><input disabled="" type="checkbox"><label for="baz" title="bar"></label></div>` {
+		t.Fatalf("got %s", html)
+	}
+	if len(node.Events["click"]) != 1 {
+		t.Fatal()
+	}
+	if SVG().Namespace == "" {
+		t.Fatal()
+	}
+}
+
+func TestGenerated(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+	out := filepath.Join(t.TempDir(), "html.go")
+	cmd := exec.Command("go", "run", "gen.go", "-out", out)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+	generated, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := os.ReadFile("html.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(generated, current) {
+		t.Fatal("html.go is out of date, run go generate")
+	}
+}
@@ -0,0 +1,229 @@
+package domui
+
+import (
+	"html"
+	"strings"
+)
+
+type htmlTokenKind uint8
+
+const (
+	htmlText htmlTokenKind = iota
+	htmlStartTag
+	htmlEndTag
+)
+
+type htmlToken struct {
+	kind        htmlTokenKind
+	data        string // unescaped text, or lower case tag name
+	name        string // tag name as written, for case-sensitive foreign elements
+	attrs       []htmlAttr
+	selfClosing bool
+}
+
+type htmlAttr struct {
+	name    string // lower case
+	rawName string // as written
+	value   string // unescaped
+}
+
+// elements with text content that are not parsed as markup
+var unparsedElements = map[string]bool{
+	"iframe": true, "noembed": true, "noframes": true, "noscript": true,
+	"plaintext": true, "script": true, "style": true, "textarea": true,
+	"title": true, "xmp": true,
+}
+
+// tokenizeHTML splits s into text and tag tokens.
+// comments, doctypes and processing instructions are dropped, like tags not closed before the end of s.
+// it does not build a tree, so no elements are implied or closed implicitly
+func tokenizeHTML(s string) []htmlToken {
+	var tokens []htmlToken
+	var text strings.Builder
+
+	flushText := func(decode bool) {
+		if text.Len() == 0 {
+			return
+		}
+		data := text.String()
+		if decode {
+			data = html.UnescapeString(data)
+		}
+		tokens = append(tokens, htmlToken{
+			kind: htmlText,
+			data: data,
+		})
+		text.Reset()
+	}
+
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			text.WriteString(s)
+			break
+		}
+		text.WriteString(s[:i])
+		s = s[i:]
+
+		switch {
+
+		case strings.HasPrefix(s, "<!--"):
+			flushText(true)
+			if end := strings.Index(s[4:], "-->"); end >= 0 {
+				s = s[4+end+3:]
+			} else {
+				s = ""
+			}
+
+		case len(s) > 1 && (s[1] == '!' || s[1] == '?'),
+			len(s) > 2 && s[1] == '/' && !isASCIILetter(s[2]):
+			// bogus comment
+			flushText(true)
+			if end := strings.IndexByte(s, '>'); end >= 0 {
+				s = s[end+1:]
+			} else {
+				s = ""
+			}
+
+		case len(s) > 1 && isASCIILetter(s[1]),
+			len(s) > 2 && s[1] == '/' && isASCIILetter(s[2]):
+			token, rest, ok := parseHTMLTag(s)
+			if !ok {
+				// unclosed tag
+				s = ""
+				break
+			}
+			flushText(true)
+			tokens = append(tokens, token)
+			s = rest
+
+			if token.kind == htmlStartTag && unparsedElements[token.data] {
+				// text until the end tag
+				end := findEndTag(s, token.data)
+				text.WriteString(s[:end])
+				flushText(token.data == "textarea" || token.data == "title")
+				s = s[end:]
+			}
+
+		default:
+			text.WriteByte('<')
+			s = s[1:]
+
+		}
+	}
+	flushText(true)
+
+	return tokens
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// findEndTag returns the index of the end tag of name in s, or len(s)
+func findEndTag(s string, name string) int {
+	offset := 0
+	for {
+		i := strings.Index(s[offset:], "</")
+		if i < 0 {
+			return len(s)
+		}
+		i += offset
+		rest := s[i+2:]
+		if len(rest) >= len(name) &&
+			strings.EqualFold(rest[:len(name)], name) &&
+			(len(rest) == len(name) || isHTMLSpace(rest[len(name)]) || rest[len(name)] == '/' || rest[len(name)] == '>') {
+			return i
+		}
+		offset = i + 2
+	}
+}
+
+// parseHTMLTag parses the start or end tag at the beginning of s
+func parseHTMLTag(s string) (token htmlToken, rest string, ok bool) {
+	token.kind = htmlStartTag
+	i := 1
+	if s[i] == '/' {
+		token.kind = htmlEndTag
+		i++
+	}
+
+	start := i
+	for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '/' && s[i] != '>' {
+		i++
+	}
+	token.name = s[start:i]
+	token.data = strings.ToLower(token.name)
+
+	seen := make(map[string]bool)
+	for {
+		for i < len(s) && (isHTMLSpace(s[i]) || s[i] == '/') {
+			if s[i] == '/' && i+1 < len(s) && s[i+1] == '>' {
+				token.selfClosing = true
+			}
+			i++
+		}
+		if i >= len(s) {
+			return token, "", false
+		}
+		if s[i] == '>' {
+			i++
+			break
+		}
+
+		// name
+		start := i
+		i++ // a leading = is part of the name
+		for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '/' && s[i] != '>' && s[i] != '=' {
+			i++
+		}
+		rawName := s[start:i]
+		name := strings.ToLower(rawName)
+		for i < len(s) && isHTMLSpace(s[i]) {
+			i++
+		}
+
+		// value
+		var value string
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isHTMLSpace(s[i]) {
+				i++
+			}
+			if i >= len(s) {
+				return token, "", false
+			}
+			switch q := s[i]; q {
+			case '"', '\'':
+				end := strings.IndexByte(s[i+1:], q)
+				if end < 0 {
+					return token, "", false
+				}
+				value = s[i+1 : i+1+end]
+				i += end + 2
+			default:
+				start := i
+				for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				value = s[start:i]
+			}
+		}
+
+		// the first of duplicated attributes is used
+		if token.kind == htmlStartTag && !seen[name] {
+			seen[name] = true
+			token.attrs = append(token.attrs, htmlAttr{
+				name:    name,
+				rawName: rawName,
+				value:   html.UnescapeString(value),
+			})
+		}
+	}
+
+	return token, s[i:], true
+}
@@ -0,0 +1,297 @@
+package domui
+
+import (
+	"sort"
+	"strings"
+)
+
+// Hydrate makes the App adopt the server rendered markup in the render element, instead of replacing it
+type Hydrate bool
+
+func (_ Def) Hydrate() Hydrate {
+	return false
+}
+
+// HydrationMismatch describes a difference between the adopted markup and the first rendered node tree
+type HydrationMismatch struct {
+	Node    *Node
+	Element DOMNode
+	Reason  string
+}
+
+type OnHydrationMismatch func(HydrationMismatch)
+
+func (_ Def) OnHydrationMismatch() OnHydrationMismatch {
+	return func(mismatch HydrationMismatch) {
+		warn("hydration mismatch: %s", mismatch.Reason)
+	}
+}
+
+type hydrator struct {
+	scope      Scope
+	app        *App
+	mismatches []HydrationMismatch
+}
+
+// hydrate adopts element and its next siblings as the rendered elements of node, fixing mismatches.
+// element is the first child of parent, or nil if parent has no child. Extra children of parent are removed.
+// mismatches are returned instead of reported, since the handler may call Update
+func hydrate(
+	scope Scope,
+	node *Node,
+	element DOMNode,
+	parent DOMNode,
+) (
+	_ DOMNode,
+	mismatches []HydrationMismatch,
+	err error,
+) {
+	defer he(&err)
+	h := &hydrator{
+		scope: scope,
+	}
+	scope.Assign(&h.app)
+	element = h.hydrate(node, parent, 0)
+	for n := parent.NumChildNodes(); n > node.span(); n-- {
+		parent.ChildNode(n - 1).Remove()
+	}
+	return element, h.mismatches, nil
+}
+
+func (h *hydrator) report(mismatch HydrationMismatch) {
+	h.mismatches = append(h.mismatches, mismatch)
+}
+
+func nodeName(element DOMNode) string {
+	name, _ := element.GetProperty("nodeName").(string)
+	return name
+}
+
+// hydrate the i-th child of parent, returns the adopted or created element
+func (h *hydrator) hydrate(node *Node, parent DOMNode, i int) DOMNode {
+	element := parent.ChildNode(i)
+
+	mismatch := func(format string, args ...any) {
+		h.report(HydrationMismatch{
+			Node:    node,
+			Element: element,
+			Reason:  sp(format, args...),
+		})
+	}
+
+	insert := func() DOMNode {
+		newElement, err := node.ToElement(h.scope)
+		ce(err)
+		parent.InsertBefore(newElement, element)
+		return newElement
+	}
+
+	replace := func() DOMNode {
+		newElement := insert()
+		h.app.removeElement(element)
+		return newElement
+	}
+
+	if node.Kind == PortalNode {
+		// portals are not serialized
+		return insert()
+	}
+
+	if node.Kind == FragmentNode {
+		var first DOMNode
+		for _, childNode := range node.children() {
+			childElement := h.hydrate(childNode, parent, i)
+			if first == nil {
+				first = childElement
+			}
+			i += childNode.span()
+		}
+		return first
+	}
+
+	if element == nil {
+		if node.Kind == TextNode && node.Text == "" {
+			// empty text nodes are not serialized
+			return insert()
+		}
+		mismatch("missing node for %s", node.describe())
+		return insert()
+	}
+
+	switch node.Kind {
+
+	case TextNode:
+		if nodeName(element) != "#text" {
+			if node.Text == "" {
+				return insert()
+			}
+			mismatch("expecting text %q, got %s", node.Text, nodeName(element))
+			return replace()
+		}
+		data := element.NodeValue()
+		if data == node.Text {
+			return element
+		}
+		if node.Text == "" {
+			return insert()
+		}
+		if strings.HasPrefix(data, node.Text) {
+			// adjacent text nodes are merged in serialized markup
+			element.SetData(node.Text)
+			parent.InsertBefore(
+				h.newText(data[len(node.Text):]),
+				parent.ChildNode(i+1),
+			)
+			return element
+		}
+		mismatch("expecting text %q, got %q", node.Text, data)
+		element.SetData(node.Text)
+		return element
+
+	case TagNode, RawHTMLNode:
+		if !element.IsElement() || !strings.EqualFold(nodeName(element), node.Text) {
+			mismatch("expecting %s, got %s", node.describe(), nodeName(element))
+			return replace()
+		}
+		if ns := node.namespace(); element.GetProperty("namespaceURI") != ns {
+			mismatch("expecting %s in namespace %s, got %v", node.describe(), ns, element.GetProperty("namespaceURI"))
+			return replace()
+		}
+
+	}
+
+	// id
+	if v, ok := element.GetAttribute("id"); node.ID != "" && v != node.ID {
+		mismatch("expecting id %q, got %q", node.ID, v)
+		element.SetProperty("id", node.ID)
+		element.SetAttribute("id", node.ID)
+	} else if node.ID == "" && ok {
+		mismatch("unexpected id %q", v)
+		element.RemoveAttribute("id")
+	}
+
+	// classes
+	v, ok := element.GetAttribute("class")
+	classes := strings.Fields(v)
+	sort.Strings(classes)
+	if expected := node.classText(); strings.Join(classes, " ") != expected {
+		mismatch("expecting class %q, got %q", expected, v)
+		if expected == "" {
+			element.RemoveAttribute("class")
+		} else {
+			element.SetAttribute("class", expected)
+		}
+	} else if expected == "" && ok {
+		element.RemoveAttribute("class")
+	}
+
+	// attributes
+	for _, item := range node.Attributes {
+		value := h.app.resolveLink(item.Value)
+		expected, present := attrValue(item.Key, value)
+		if v, ok := element.GetAttribute(item.Key); ok != present || v != expected {
+			mismatch("expecting attribute %s=%q, got %q", item.Key, expected, v)
+			setAttr(element, item.Key, value)
+		}
+	}
+	for _, name := range element.AttributeNames() {
+		switch name {
+		case "id", "class", "style":
+			continue
+		}
+		if node.hasAttribute(name) {
+			continue
+		}
+		v, _ := element.GetAttribute(name)
+		mismatch("unexpected attribute %s=%q", name, v)
+		removeAttr(element, name)
+	}
+
+	// props
+	for _, item := range node.Props {
+		element.SetProperty(item.Key, item.Value)
+	}
+
+	// style
+	v, _ = element.GetAttribute("style")
+	if expected := node.styleText(); styleText(parseStyleText(v)) != expected {
+		mismatch("expecting style %q, got %q", expected, v)
+		if expected == "" {
+			element.RemoveAttribute("style")
+		} else {
+			element.SetStyleText(expected)
+		}
+	}
+
+	// child nodes
+	if node.Kind == RawHTMLNode {
+		// serializations of the same content may differ, not reported as mismatch
+		if element.GetProperty("innerHTML") != node.InnerHTML {
+			element.SetProperty("innerHTML", node.InnerHTML)
+		}
+	} else {
+		offset := 0
+		for _, childNode := range node.childNodes {
+			h.hydrate(childNode, element, offset)
+			offset += childNode.span()
+		}
+		for n := element.NumChildNodes(); n > offset; n-- {
+			extra := element.ChildNode(n - 1)
+			h.report(HydrationMismatch{
+				Node:    node,
+				Element: extra,
+				Reason:  sp("unexpected %s in %s", nodeName(extra), node.describe()),
+			})
+			h.app.removeElement(extra)
+		}
+	}
+
+	// binding
+	if node.Binding != nil {
+		ce(node.Binding.apply(element))
+	}
+
+	// events
+	if len(node.Events) > 0 {
+		h.app.setEventSpecs(element, node.Events)
+	}
+	if len(node.GlobalEvents) > 0 {
+		h.app.setGlobalEventSpecs(element, node.GlobalEvents)
+	}
+	if len(node.Shortcuts) > 0 {
+		h.app.setShortcuts(h.scope, element, node.Shortcuts)
+	}
+
+	// lifecycle
+	h.app.setHooks(element, node, Mounted)
+
+	// focus
+	if node.Focus {
+		h.app.focus(element)
+	}
+
+	return element
+}
+
+func (h *hydrator) newText(data string) DOMNode {
+	var dom DOM
+	h.scope.Assign(&dom)
+	return dom.CreateTextNode(data)
+}
+
+// hasAttribute reports whether name is one of the attributes of n, case-insensitive for html elements
+func (n *Node) hasAttribute(name string) bool {
+	for _, item := range n.Attributes {
+		if item.Key == name || n.namespace() == HTMLNamespace && strings.EqualFold(item.Key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *Node) describe() string {
+	if n.Kind == TextNode {
+		return sp("text %q", n.Text)
+	}
+	return sp("<%s>", strings.ToLower(n.Text))
+}
@@ -0,0 +1,214 @@
+package domui
+
+import (
+	"testing"
+)
+
+func TestHydrate(t *testing.T) {
+	dom := NewMemDOM()
+	parent := dom.CreateElement("div")
+	dom.Body().AppendChild(parent)
+
+	// server rendered markup, with adjacent texts merged and some mismatches
+	root := dom.CreateElement("div")
+	root.SetAttribute("id", "main")
+	parent.AppendChild(root)
+	p := dom.CreateElement("p")
+	p.AppendChild(dom.CreateTextNode("hello, world"))
+	root.AppendChild(p)
+	span := dom.CreateElement("span")
+	span.SetAttribute("class", "b")
+	span.AppendChild(dom.CreateTextNode("1"))
+	root.AppendChild(span)
+	root.AppendChild(dom.CreateElement("i"))
+
+	var mismatches []HydrationMismatch
+	defs := []any{
+		func() int {
+			return 1
+		},
+		func(n int) RootElement {
+			return Div(
+				ID("main"),
+				P(
+					Text("hello, "),
+					Text("world"),
+				),
+				Tag("span")(
+					Class("a"),
+					Text("%d", n),
+				),
+				OnClick(func() {}),
+			)
+		},
+	}
+
+	app := NewDOMApp(
+		dom,
+		parent,
+		append(defs,
+			func() Hydrate {
+				return true
+			},
+			func() OnHydrationMismatch {
+				return func(mismatch HydrationMismatch) {
+					mismatches = append(mismatches, mismatch)
+				}
+			},
+		)...,
+	)
+	defer app.Close()
+
+	if !app.element.Equal(root) {
+		t.Fatal("root element not adopted")
+	}
+	if !root.ChildNode(0).Equal(p) {
+		t.Fatal("child element not adopted")
+	}
+	if p.NumChildNodes() != 2 {
+		t.Fatalf("got %d", p.NumChildNodes())
+	}
+	if len(mismatches) != 2 {
+		t.Fatalf("got %+v", mismatches)
+	}
+	expected, err := RenderToString(defs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if html := app.HTML(); html != expected {
+		t.Fatalf("got %s", html)
+	}
+
+	id, ok := elementIDOf(root)
+	if !ok {
+		t.Fatal("events not set")
+	}
+	app.eventsLock.RLock()
+	n := len(app.eventRegistry[id]["click"])
+	app.eventsLock.RUnlock()
+	if n != 1 {
+		t.Fatal()
+	}
+
+	app.Update(func() int {
+		return 2
+	})
+	app.Render()
+	if !app.element.Equal(root) {
+		t.Fatal()
+	}
+	if html := app.HTML(); html != `<div id="main"><p>hello, world</p><span class="a">2</span></div>` {
+		t.Fatalf("got %s", html)
+	}
+}
+
+func TestHydrateMismatchUpdate(t *testing.T) {
+	dom := NewMemDOM()
+	parent := dom.CreateElement("div")
+	dom.Body().AppendChild(parent)
+	root := dom.CreateElement("div")
+	root.SetAttribute("data-x", "1")
+	root.SetAttribute("title", "foo")
+	parent.AppendChild(root)
+
+	var reasons []string
+	app := NewDOMApp(
+		dom,
+		parent,
+		func() int {
+			return 1
+		},
+		func(n int) RootElement {
+			return Div(
+				Attr("title")("foo"),
+				Text("%d", n),
+			)
+		},
+		func() Hydrate {
+			return true
+		},
+		func(app *App) OnHydrationMismatch {
+			return func(mismatch HydrationMismatch) {
+				reasons = append(reasons, mismatch.Reason)
+				// not holding the lock
+				app.Update(func() int {
+					return 2
+				})
+			}
+		},
+	)
+	defer app.Close()
+
+	if len(reasons) != 2 {
+		t.Fatalf("got %q", reasons)
+	}
+	if reasons[0] != `unexpected attribute data-x="1"` {
+		t.Fatalf("got %q", reasons[0])
+	}
+	if _, ok := root.GetAttribute("data-x"); ok {
+		t.Fatal()
+	}
+	app.Render()
+	if html := app.HTML(); html != `<div title="foo">2</div>` {
+		t.Fatalf("got %s", html)
+	}
+}
+
+func TestHydrateInnerHTML(t *testing.T) {
+	defs := []any{
+		func() RootElement {
+			return Div(
+				Tag("svg")(
+					Attr("viewBox")("0 0 10 10"),
+					Tag("linearGradient")(ID("g")),
+					Tag("use")(Attr("xlink:href")("#g")),
+					Tag("foreignObject")(Tag("p")(Text("foo"))),
+				),
+				Tag("math")(Tag("mi")(Text("x"))),
+				Tag("br")(),
+			)
+		},
+	}
+	html, err := RenderToString(defs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// server rendered markup loaded through innerHTML
+	dom := NewMemDOM()
+	parent := dom.CreateElement("div")
+	dom.Body().AppendChild(parent)
+	parent.SetProperty("innerHTML", html)
+	root := parent.ChildNode(0)
+
+	var mismatches []HydrationMismatch
+	app := NewDOMApp(
+		dom,
+		parent,
+		append(defs,
+			func() Hydrate {
+				return true
+			},
+			func() OnHydrationMismatch {
+				return func(mismatch HydrationMismatch) {
+					mismatches = append(mismatches, mismatch)
+				}
+			},
+		)...,
+	)
+	defer app.Close()
+
+	if len(mismatches) != 0 {
+		t.Fatalf("got %+v", mismatches)
+	}
+	if !app.element.Equal(root) {
+		t.Fatal("root element not adopted")
+	}
+	if got := app.HTML(); got != html {
+		t.Fatalf("got %s", got)
+	}
+	foreign := root.ChildNode(0).ChildNode(2)
+	if ns := foreign.ChildNode(0).GetProperty("namespaceURI"); ns != HTMLNamespace {
+		t.Fatalf("got %v", ns)
+	}
+}
@@ -1,16 +1,26 @@
+//go:build js && wasm
+
 package domui
 
 import (
 	"fmt"
+	"strings"
 	"syscall/js"
 )
 
 var (
-	global      = js.Global()
-	console     = global.Get("console")
-	document    = global.Get("document")
-	htmlElement = global.Get("HTMLElement")
-	body        = document.Get("body")
+	global       = js.Global()
+	console      = global.Get("console")
+	document     = global.Get("document")
+	htmlElement  = global.Get("HTMLElement")
+	elementClass = global.Get("Element")
+	body         = document.Get("body")
+)
+
+// macPlatform reports whether the mod shortcut modifier is meta
+var macPlatform = strings.Contains(
+	strings.ToLower(global.Get("navigator").Get("platform").String()),
+	"mac",
 )
 
 func log(format string, args ...any) {
@@ -28,3 +38,13 @@ func warn(format string, args ...any) {
 func pr(args ...any) {
 	console.Call("log", args...)
 }
+
+// nativeNode converts js.Value to DOMNode
+func nativeNode(v any) (DOMNode, bool) {
+	if value, ok := v.(js.Value); ok {
+		if node := JSNode(value); node != nil {
+			return node, true
+		}
+	}
+	return nil, false
+}
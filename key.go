@@ -0,0 +1,161 @@
+package domui
+
+type KeySpec struct {
+	Value any
+}
+
+func (_ KeySpec) IsSpec() {}
+
+// Key sets the identity of a node among its siblings.
+// Keyed children are reconciled by key, moving existing elements instead of patching them in place.
+// key must be comparable
+func Key(key any) KeySpec {
+	return KeySpec{
+		Value: key,
+	}
+}
+
+func hasKeys(nodes []*Node) bool {
+	for _, node := range nodes {
+		if node.Key != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// unkeyed nodes are matched by their order among unkeyed siblings
+type unkeyedKey int
+
+func childKeys(nodes []*Node) []any {
+	keys := make([]any, len(nodes))
+	n := 0
+	for i, node := range nodes {
+		if node.Key != nil {
+			keys[i] = node.Key
+		} else {
+			keys[i] = unkeyedKey(n)
+			n++
+		}
+	}
+	return keys
+}
+
+func patchKeyedChildren(
+	scope Scope,
+	parent DOMNode,
+	first DOMNode,
+	end DOMNode,
+	childNodes []*Node,
+	lastChildNodes []*Node,
+) (_ DOMNode, err error) {
+	defer he(&err)
+
+	var app *App
+	scope.Assign(&app)
+
+	// first DOM nodes of last child nodes
+	lastElements := make([]DOMNode, len(lastChildNodes))
+	cursor := first
+	for i, lastChildNode := range lastChildNodes {
+		lastElements[i] = cursor
+		cursor = skipElements(cursor, lastChildNode.span())
+	}
+
+	// match by key
+	lastIndexes := make(map[any]int)
+	for i, key := range childKeys(lastChildNodes) {
+		if _, ok := lastIndexes[key]; ok {
+			warn("duplicated key: %v", key)
+			continue
+		}
+		lastIndexes[key] = i
+	}
+	sources := make([]int, len(childNodes))
+	matched := make([]bool, len(lastChildNodes))
+	for i, key := range childKeys(childNodes) {
+		j, ok := lastIndexes[key]
+		if !ok || matched[j] {
+			sources[i] = -1
+			continue
+		}
+		sources[i] = j
+		matched[j] = true
+	}
+
+	// remove unmatched
+	for i, lastElement := range lastElements {
+		if matched[i] {
+			continue
+		}
+		app.removeElements(lastElement, lastChildNodes[i].span())
+	}
+
+	// elements in the longest increasing subsequence stay, others move
+	stay := longestIncreasingSubsequence(sources)
+	next := end
+	for i := len(childNodes) - 1; i >= 0; i-- {
+		var childElement DOMNode
+		if j := sources[i]; j < 0 {
+			// create
+			newElement, err := childNodes[i].ToElement(scope)
+			ce(err)
+			childElement = childNodes[i].firstElement(newElement)
+			parent.InsertBefore(newElement, next)
+		} else {
+			// patch and move
+			childElement, err = patch(scope, childNodes[i], lastElements[j], lastChildNodes[j])
+			ce(err)
+			if !stay[i] {
+				for _, e := range siblingElements(childElement, childNodes[i].span()) {
+					parent.InsertBefore(e, next)
+				}
+			}
+		}
+		next = childElement
+	}
+
+	if len(childNodes) == 0 {
+		return nil, nil
+	}
+	return next, nil
+}
+
+// longestIncreasingSubsequence returns the indexes of the longest strictly increasing subsequence of non-negative values
+func longestIncreasingSubsequence(values []int) []bool {
+	// tails[k] is the index of the smallest tail of increasing subsequences of length k+1
+	var tails []int
+	prev := make([]int, len(values))
+	for i, v := range values {
+		if v < 0 {
+			continue
+		}
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if values[tails[mid]] < v {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		if lo > 0 {
+			prev[i] = tails[lo-1]
+		} else {
+			prev[i] = -1
+		}
+		if lo == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[lo] = i
+		}
+	}
+	ret := make([]bool, len(values))
+	if len(tails) == 0 {
+		return ret
+	}
+	for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+		ret[i] = true
+	}
+	return ret
+}
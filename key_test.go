@@ -0,0 +1,102 @@
+package domui
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestKeyedChildren(t *testing.T) {
+	WithTestApp(
+		t,
+		func(app *App) {
+			elements := make(map[int]DOMNode)
+			for i := 0; i < app.element.NumChildNodes(); i++ {
+				elements[i] = app.element.ChildNode(i)
+			}
+
+			check := func(list []int) {
+				t.Helper()
+				var b strings.Builder
+				b.WriteString("<div>")
+				for _, i := range list {
+					b.WriteString(sp("<p>%d</p>", i))
+				}
+				b.WriteString("</div>")
+				if html := app.HTML(); html != b.String() {
+					t.Fatalf("got %s", html)
+				}
+				current := make(map[int]DOMNode)
+				for j, i := range list {
+					element := app.element.ChildNode(j)
+					if e, ok := elements[i]; ok && !e.Equal(element) {
+						t.Fatalf("element of key %d not reused", i)
+					}
+					current[i] = element
+				}
+				elements = current
+			}
+
+			check([]int{0, 1, 2, 3, 4})
+			for _, list := range [][]int{
+				{4, 3, 2, 1, 0},
+				{1, 0, 5, 3, 2},
+				{2},
+				{},
+				{6, 7},
+				{7, 6, 2},
+			} {
+				app.Update(func() []int {
+					return list
+				})
+				app.Render()
+				check(list)
+			}
+
+			for range 64 {
+				list := rand.Perm(8)[:rand.Intn(8)]
+				app.Update(func() []int {
+					return list
+				})
+				app.Render()
+				check(list)
+			}
+		},
+		func() []int {
+			return []int{0, 1, 2, 3, 4}
+		},
+		func(list []int) RootElement {
+			return Div(
+				For(list, func(i int) Spec {
+					return P(
+						Key(i),
+						Text("%d", i),
+					)
+				}),
+			)
+		},
+	)
+}
+
+func TestLongestIncreasingSubsequence(t *testing.T) {
+	for _, c := range []struct {
+		values   []int
+		expected []bool
+	}{
+		{nil, []bool{}},
+		{[]int{-1, -1}, []bool{false, false}},
+		{[]int{0, 1, 2}, []bool{true, true, true}},
+		{[]int{2, 1, 0}, []bool{false, false, true}},
+		{[]int{3, -1, 0, 4, 1, 2}, []bool{false, false, true, false, true, true}},
+	} {
+		got := longestIncreasingSubsequence(c.values)
+		if len(got) != len(c.expected) {
+			t.Fatalf("got %v", got)
+		}
+		for i := range got {
+			if got[i] != c.expected[i] {
+				t.Fatalf("%v: got %v", c.values, got)
+			}
+		}
+	}
+}
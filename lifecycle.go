@@ -0,0 +1,142 @@
+package domui
+
+import "sync/atomic"
+
+type LifecycleEvent uint8
+
+const (
+	// after the element is inserted into the DOM
+	Mounted LifecycleEvent = iota
+	// after the element is patched
+	Updated
+	// before the element is removed from the DOM
+	Unmounting
+)
+
+type LifecycleSpec struct {
+	Event LifecycleEvent
+	Func  any
+}
+
+func (_ LifecycleSpec) IsSpec() {}
+
+// OnMount calls fn after the element is inserted into the DOM.
+// Like event handlers, fn can accept the element as DOMNode or js.Value
+func OnMount(fn any) LifecycleSpec {
+	return LifecycleSpec{
+		Event: Mounted,
+		Func:  fn,
+	}
+}
+
+// OnUpdate calls fn after the element is patched by a render
+func OnUpdate(fn any) LifecycleSpec {
+	return LifecycleSpec{
+		Event: Updated,
+		Func:  fn,
+	}
+}
+
+// OnUnmount calls fn before the element or any of its ancestors is removed.
+// fn is called while rendering, it must not call Update synchronously
+func OnUnmount(fn any) LifecycleSpec {
+	return LifecycleSpec{
+		Event: Unmounting,
+		Func:  fn,
+	}
+}
+
+func callWithElement(element DOMNode, fn any) {
+	eventHandlerScope.Fork(
+		handlerDefs(element)...,
+	).Call(fn)
+}
+
+// lastElementID is shared by all Apps, nested Apps see the ids of each other in the same property
+var lastElementID int32 = 42
+
+// focus focuses element with the hooks, not holding the lock,
+// since browsers dispatch focus events synchronously to Sync handlers, which may call Update
+func (a *App) focus(element DOMNode) {
+	a.pendingHooks = append(a.pendingHooks, element.Focus)
+}
+
+func (a *App) ensureElementID(element DOMNode) int32 {
+	id, ok := elementIDOf(element)
+	if !ok {
+		id = atomic.AddInt32(&lastElementID, 1)
+		element.SetProperty("__element_id__", id)
+	}
+	return id
+}
+
+// setHooks registers unmount hooks of node, and queues hooks of event to be called after rendering.
+// refs of node are also set and cleared on unmount
+func (a *App) setHooks(element DOMNode, node *Node, event LifecycleEvent) {
+	var unmount []any
+	for _, ref := range node.Refs {
+		ref.set(element)
+		unmount = append(unmount, func() {
+			ref.unset(element)
+		})
+	}
+	for _, hook := range node.Hooks {
+		switch hook.Event {
+		case Unmounting:
+			unmount = append(unmount, hook.Func)
+		case event:
+			fn := hook.Func
+			a.pendingHooks = append(a.pendingHooks, func() {
+				callWithElement(element, fn)
+			})
+		}
+	}
+
+	if len(unmount) > 0 {
+		id := a.ensureElementID(element)
+		a.eventsLock.Lock()
+		a.unmountHooks[id] = unmount
+		a.eventsLock.Unlock()
+	} else if id, ok := elementIDOf(element); ok {
+		a.eventsLock.Lock()
+		delete(a.unmountHooks, id)
+		a.eventsLock.Unlock()
+	}
+}
+
+// removeElement releases and removes element
+func (a *App) removeElement(element DOMNode) {
+	a.releaseElement(element)
+	element.Remove()
+}
+
+// releaseElement calls unmount hooks and unsets event handlers of element and its descendants
+func (a *App) releaseElement(element DOMNode) {
+	if id, ok := elementIDOf(element); ok {
+		a.eventsLock.Lock()
+		hooks := a.unmountHooks[id]
+		delete(a.unmountHooks, id)
+		delete(a.eventRegistry, id)
+		delete(a.onceCalled, id)
+		delete(a.globalRegistry, id)
+		delete(a.shortcuts, id)
+		limiters := a.limiters[id]
+		delete(a.limiters, id)
+		portal := a.portals[id]
+		delete(a.portals, id)
+		delete(a.portalContainers, id)
+		a.eventsLock.Unlock()
+		for _, l := range limiters {
+			l.release()
+		}
+		if portal != nil {
+			a.releasePortal(portal)
+		}
+		for _, fn := range hooks {
+			callWithElement(element, fn)
+		}
+	}
+	for i := element.NumChildNodes() - 1; i >= 0; i-- {
+		a.releaseElement(element.ChildNode(i))
+	}
+}
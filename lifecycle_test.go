@@ -0,0 +1,114 @@
+package domui
+
+import (
+	"testing"
+)
+
+func TestLifecycle(t *testing.T) {
+	var events []string
+	record := func(name string) func(DOMNode) {
+		return func(element DOMNode) {
+			if !element.(*MemNode).isConnected() {
+				t.Fatalf("%s: element not in document", name)
+			}
+			events = append(events, name)
+		}
+	}
+	check := func(expected ...string) {
+		t.Helper()
+		if len(events) != len(expected) {
+			t.Fatalf("got %v", events)
+		}
+		for i, e := range expected {
+			if events[i] != e {
+				t.Fatalf("got %v", events)
+			}
+		}
+		events = events[:0]
+	}
+
+	app := func() *App {
+		dom := NewMemDOM()
+		parent := dom.CreateElement("div")
+		dom.Body().AppendChild(parent)
+		return NewDOMApp(
+			dom,
+			parent,
+			func() int {
+				return 1
+			},
+			func(n int) RootElement {
+				if n == 0 {
+					return Div()
+				}
+				return Div(
+					OnMount(record("mount div")),
+					OnUpdate(record("update div")),
+					OnUnmount(record("unmount div")),
+					P(
+						Tag("span")(
+							Text("%d", n),
+							OnMount(record("mount span")),
+							OnUnmount(record("unmount span")),
+						),
+					),
+				)
+			},
+		)
+	}()
+	defer app.Close()
+	check("mount span", "mount div")
+
+	app.Update(func() int {
+		return 2
+	})
+	app.Render()
+	check("update div")
+
+	app.Update(func() int {
+		return 0
+	})
+	app.Render()
+	// div is patched, not removed
+	check("unmount span")
+
+	app.Update(func() int {
+		return 3
+	})
+	app.Render()
+	check("mount span", "update div")
+
+	app.Close()
+	check("unmount div", "unmount span")
+}
+
+func TestLifecycleInReplacedChild(t *testing.T) {
+	unmounted := 0
+	WithTestApp(
+		t,
+		func(app *App) {
+			app.Update(func() bool {
+				return false
+			})
+			app.Render()
+			if unmounted != 1 {
+				t.Fatal()
+			}
+		},
+		func() bool {
+			return true
+		},
+		func(b bool) RootElement {
+			if !b {
+				return Div(P())
+			}
+			return Div(
+				Tag("span")(
+					OnUnmount(func() {
+						unmounted++
+					}),
+				),
+			)
+		},
+	)
+}
@@ -0,0 +1,1030 @@
+package domui
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MemDOM is a pure-Go in-memory DOM backend for headless rendering and tests
+type MemDOM struct {
+	window     *MemNode
+	document   *MemNode
+	body       *MemNode
+	active     *MemNode
+	framesLock sync.Mutex
+	frames     []func()
+}
+
+var _ DOM = new(MemDOM)
+
+// NewMemDOM creates a MemDOM containing an empty html document
+func NewMemDOM() *MemDOM {
+	dom := new(MemDOM)
+	dom.window = &MemNode{
+		dom:  dom,
+		kind: memWindow,
+		tag:  "#window",
+	}
+	dom.document = &MemNode{
+		dom:  dom,
+		kind: memDocument,
+		tag:  "#document",
+	}
+	html := dom.createElement("html")
+	html.AppendChild(dom.createElement("head"))
+	dom.body = dom.createElement("body")
+	html.AppendChild(dom.body)
+	dom.document.AppendChild(html)
+	return dom
+}
+
+// Body returns the body element of the document
+func (d *MemDOM) Body() *MemNode {
+	return d.body
+}
+
+func (d *MemDOM) createElement(tag string) *MemNode {
+	return &MemNode{
+		dom:  d,
+		kind: memElement,
+		tag:  strings.ToLower(tag),
+		ns:   HTMLNamespace,
+	}
+}
+
+func (d *MemDOM) CreateElement(tag string) DOMNode {
+	return d.createElement(tag)
+}
+
+func (d *MemDOM) CreateElementNS(namespace string, tag string) DOMNode {
+	if namespace == HTMLNamespace {
+		return d.createElement(tag)
+	}
+	// names of foreign elements are case-sensitive
+	return &MemNode{
+		dom:  d,
+		kind: memElement,
+		tag:  tag,
+		ns:   namespace,
+	}
+}
+
+func (d *MemDOM) CreateTextNode(data string) DOMNode {
+	return &MemNode{
+		dom:  d,
+		kind: memText,
+		data: data,
+	}
+}
+
+func (d *MemDOM) CreateDocumentFragment() DOMNode {
+	return &MemNode{
+		dom:  d,
+		kind: memFragment,
+	}
+}
+
+func (d *MemDOM) ActiveElement() DOMNode {
+	if d.active != nil && d.active.isConnected() {
+		return d.active
+	}
+	return d.body
+}
+
+// Window returns the window, events dispatched in the document bubble to it
+func (d *MemDOM) Window() EventTarget {
+	return d.window
+}
+
+func (d *MemDOM) Document() EventTarget {
+	return d.document
+}
+
+// QuerySelector supports compound selectors of tag, id and classes, like "div#main.foo"
+func (d *MemDOM) QuerySelector(selector string) DOMNode {
+	if node := d.document.querySelector(parseSimpleSelector(selector)); node != nil {
+		return node
+	}
+	return nil
+}
+
+type simpleSelector struct {
+	tag     string
+	id      string
+	classes []string
+}
+
+func parseSimpleSelector(selector string) (ret simpleSelector) {
+	selector = strings.TrimSpace(selector)
+	part := func() string {
+		i := strings.IndexAny(selector[1:], "#.") + 1
+		if i == 0 {
+			i = len(selector)
+		}
+		s := selector[1:i]
+		selector = selector[i:]
+		return s
+	}
+	if i := strings.IndexAny(selector, "#."); i != 0 {
+		if i < 0 {
+			i = len(selector)
+		}
+		ret.tag = strings.ToLower(selector[:i])
+		selector = selector[i:]
+	}
+	for selector != "" {
+		switch selector[0] {
+		case '#':
+			ret.id = part()
+		case '.':
+			ret.classes = append(ret.classes, part())
+		}
+	}
+	return
+}
+
+func (n *MemNode) querySelector(selector simpleSelector) *MemNode {
+	for _, child := range n.children {
+		if child.matches(selector) {
+			return child
+		}
+		if node := child.querySelector(selector); node != nil {
+			return node
+		}
+	}
+	return nil
+}
+
+func (n *MemNode) matches(selector simpleSelector) bool {
+	if n.kind != memElement {
+		return false
+	}
+	if selector.tag != "" && selector.tag != "*" && !strings.EqualFold(selector.tag, n.tag) {
+		return false
+	}
+	if selector.id != "" {
+		if id, _ := n.GetAttribute("id"); id != selector.id {
+			return false
+		}
+	}
+	classes := n.classList()
+	for _, class := range selector.classes {
+		if !slices.Contains(classes, class) {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *MemDOM) RequestAnimationFrame(fn func()) {
+	d.framesLock.Lock()
+	defer d.framesLock.Unlock()
+	d.frames = append(d.frames, fn)
+}
+
+// Frame runs the callbacks requested by RequestAnimationFrame, and reports whether there were any
+func (d *MemDOM) Frame() bool {
+	d.framesLock.Lock()
+	frames := d.frames
+	d.frames = nil
+	d.framesLock.Unlock()
+	for _, fn := range frames {
+		fn()
+	}
+	return len(frames) > 0
+}
+
+type memNodeKind uint8
+
+const (
+	memElement memNodeKind = iota
+	memText
+	memFragment
+	memDocument
+	memWindow
+)
+
+type memAttr struct {
+	name  string
+	value string
+	ns    string
+}
+
+type memListener struct {
+	event   string
+	fn      func(DOMEvent)
+	capture bool
+	passive bool
+}
+
+// MemNode is a node of MemDOM
+type MemNode struct {
+	dom        *MemDOM
+	kind       memNodeKind
+	tag        string
+	ns         string
+	data       string
+	parent     *MemNode
+	children   []*MemNode
+	attrs      []memAttr
+	styles     []memAttr
+	styleDirty bool
+	props      map[string]any
+	listeners  []*memListener
+}
+
+var _ DOMNode = new(MemNode)
+
+func (n *MemNode) Equal(node DOMNode) bool {
+	other, ok := node.(*MemNode)
+	return ok && other == n
+}
+
+func (n *MemNode) IsElement() bool {
+	return n.kind == memElement
+}
+
+func (n *MemNode) isHTML() bool {
+	return n.ns == HTMLNamespace
+}
+
+// attrName lowercases attribute names of html elements
+func (n *MemNode) attrName(name string) string {
+	if n.isHTML() {
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+func (n *MemNode) isConnected() bool {
+	for node := n; node != nil; node = node.parent {
+		if node == n.dom.document {
+			return true
+		}
+	}
+	return false
+}
+
+// tree
+
+func (n *MemNode) ParentNode() DOMNode {
+	if n.parent == nil {
+		return nil
+	}
+	return n.parent
+}
+
+func (n *MemNode) NextSibling() DOMNode {
+	if n.parent == nil {
+		return nil
+	}
+	if i := n.parent.indexOf(n) + 1; i < len(n.parent.children) {
+		return n.parent.children[i]
+	}
+	return nil
+}
+
+func (n *MemNode) ChildNode(i int) DOMNode {
+	if i < 0 || i >= len(n.children) {
+		return nil
+	}
+	return n.children[i]
+}
+
+func (n *MemNode) NumChildNodes() int {
+	return len(n.children)
+}
+
+func (n *MemNode) AppendChild(child DOMNode) {
+	n.InsertBefore(child, nil)
+}
+
+func (n *MemNode) InsertBefore(child DOMNode, ref DOMNode) {
+	c := child.(*MemNode)
+	var nodes []*MemNode
+	if c.kind == memFragment {
+		nodes = c.children
+		c.children = nil
+	} else {
+		c.Remove()
+		nodes = []*MemNode{c}
+	}
+	i := len(n.children)
+	if r, ok := ref.(*MemNode); ok && r != nil {
+		i = n.indexOf(r)
+		if i < 0 {
+			panic("reference node is not a child")
+		}
+	}
+	for _, node := range nodes {
+		node.parent = n
+	}
+	n.children = append(
+		n.children[:i],
+		append(nodes, n.children[i:]...)...,
+	)
+}
+
+func (n *MemNode) indexOf(child *MemNode) int {
+	for i, c := range n.children {
+		if c == child {
+			return i
+		}
+	}
+	return -1
+}
+
+func (n *MemNode) Remove() {
+	if n.parent == nil {
+		return
+	}
+	parent := n.parent
+	i := parent.indexOf(n)
+	parent.children = append(parent.children[:i], parent.children[i+1:]...)
+	n.parent = nil
+}
+
+// setInnerHTML replaces the child nodes with nodes parsed from s.
+// elements are closed by matching end tags only, no elements are implied.
+// svg and math elements and their descendants are in the foreign namespaces, except children of foreignObject
+func (n *MemNode) setInnerHTML(s string) {
+	for _, child := range n.children {
+		child.parent = nil
+	}
+	n.children = nil
+	parent := n
+	for _, token := range tokenizeHTML(s) {
+		switch token.kind {
+
+		case htmlText:
+			parent.AppendChild(n.dom.CreateTextNode(token.data))
+
+		case htmlStartTag:
+			ns := parent.childNamespace(token.data)
+			if ns == HTMLNamespace {
+				element := n.dom.createElement(token.data)
+				for _, attr := range token.attrs {
+					element.SetAttribute(attr.name, attr.value)
+				}
+				parent.AppendChild(element)
+				if !voidElements[token.data] {
+					parent = element
+				}
+				break
+			}
+			// foreign names are case-sensitive, and self-closing tags are honored
+			element := n.dom.CreateElementNS(ns, token.name).(*MemNode)
+			for _, attr := range token.attrs {
+				if attrNS, _ := attrNamespace(attr.rawName); attrNS != "" {
+					element.SetAttributeNS(attrNS, attr.rawName, attr.value)
+				} else {
+					element.SetAttribute(attr.rawName, attr.value)
+				}
+			}
+			parent.AppendChild(element)
+			if !token.selfClosing {
+				parent = element
+			}
+
+		case htmlEndTag:
+			for p := parent; p != n; p = p.parent {
+				if strings.EqualFold(p.tag, token.data) {
+					parent = p.parent
+					break
+				}
+			}
+
+		}
+	}
+}
+
+// childNamespace returns the namespace of the parsed child element tag of n, like Node.inheritNamespace
+func (n *MemNode) childNamespace(tag string) string {
+	if n.kind != memElement || n.isHTML() || strings.EqualFold(n.tag, "foreignObject") {
+		if ns := tagNamespace(tag); ns != "" {
+			return ns
+		}
+		return HTMLNamespace
+	}
+	return n.ns
+}
+
+// content
+
+func (n *MemNode) NodeValue() string {
+	return n.data
+}
+
+func (n *MemNode) SetData(data string) {
+	n.data = data
+}
+
+// TextContent returns the concatenated data of all descendant text nodes
+func (n *MemNode) TextContent() string {
+	if n.kind == memText {
+		return n.data
+	}
+	var b strings.Builder
+	for _, child := range n.children {
+		b.WriteString(child.TextContent())
+	}
+	return b.String()
+}
+
+func (n *MemNode) OuterHTML() string {
+	var b strings.Builder
+	n.writeHTML(&b, false)
+	return b.String()
+}
+
+// InnerHTML returns the serialized child nodes
+func (n *MemNode) InnerHTML() string {
+	var b strings.Builder
+	raw := n.isHTML() && rawTextElements[n.tag]
+	for _, child := range n.children {
+		child.writeHTML(&b, raw)
+	}
+	return b.String()
+}
+
+var voidElements = map[string]bool{
+	"area": true, "base": true, "basefont": true, "bgsound": true,
+	"br": true, "col": true, "embed": true, "frame": true,
+	"hr": true, "img": true, "input": true, "keygen": true,
+	"link": true, "meta": true, "param": true, "source": true,
+	"track": true, "wbr": true,
+}
+
+var rawTextElements = map[string]bool{
+	"iframe": true, "noembed": true, "noframes": true, "plaintext": true,
+	"script": true, "style": true, "xmp": true,
+}
+
+var (
+	textEscaper = strings.NewReplacer(
+		"&", "&amp;",
+		"\u00a0", "&nbsp;",
+		"<", "&lt;",
+		">", "&gt;",
+	)
+	attrEscaper = strings.NewReplacer(
+		"&", "&amp;",
+		"\u00a0", "&nbsp;",
+		`"`, "&quot;",
+		"<", "&lt;",
+		">", "&gt;",
+	)
+)
+
+func (n *MemNode) writeHTML(b *strings.Builder, raw bool) {
+	switch n.kind {
+
+	case memText:
+		if raw {
+			b.WriteString(n.data)
+		} else {
+			b.WriteString(textEscaper.Replace(n.data))
+		}
+
+	case memElement:
+		n.syncStyle()
+		b.WriteString("<")
+		b.WriteString(n.tag)
+		for _, attr := range n.attrs {
+			b.WriteString(" ")
+			b.WriteString(attr.name)
+			b.WriteString(`="`)
+			b.WriteString(attrEscaper.Replace(attr.value))
+			b.WriteString(`"`)
+		}
+		b.WriteString(">")
+		if n.isHTML() && voidElements[n.tag] {
+			return
+		}
+		b.WriteString(n.InnerHTML())
+		b.WriteString("</")
+		b.WriteString(n.tag)
+		b.WriteString(">")
+
+	default:
+		for _, child := range n.children {
+			child.writeHTML(b, raw)
+		}
+
+	}
+}
+
+// attributes and properties
+
+func (n *MemNode) GetAttribute(name string) (string, bool) {
+	name = n.attrName(name)
+	if name == "style" {
+		n.syncStyle()
+	}
+	for _, attr := range n.attrs {
+		if attr.name == name {
+			return attr.value, true
+		}
+	}
+	return "", false
+}
+
+func (n *MemNode) AttributeNames() []string {
+	n.syncStyle()
+	names := make([]string, 0, len(n.attrs))
+	for _, attr := range n.attrs {
+		names = append(names, attr.name)
+	}
+	return names
+}
+
+func (n *MemNode) setAttr(name string, value string) {
+	for i, attr := range n.attrs {
+		if attr.name == name {
+			n.attrs[i].value = value
+			return
+		}
+	}
+	n.attrs = append(n.attrs, memAttr{
+		name:  name,
+		value: value,
+	})
+}
+
+func (n *MemNode) SetAttribute(name string, value any) {
+	name = n.attrName(name)
+	str := jsString(value)
+	if name == "style" {
+		n.styles = parseStyleText(str)
+		n.styleDirty = false
+	}
+	n.setAttr(name, str)
+}
+
+func (n *MemNode) RemoveAttribute(name string) {
+	name = n.attrName(name)
+	if name == "style" {
+		n.styles = nil
+		n.styleDirty = false
+	}
+	for i, attr := range n.attrs {
+		if attr.name == name {
+			n.attrs = append(n.attrs[:i], n.attrs[i+1:]...)
+			return
+		}
+	}
+}
+
+func (n *MemNode) SetAttributeNS(namespace string, name string, value any) {
+	_, local, ok := strings.Cut(name, ":")
+	if !ok {
+		local = name
+	}
+	str := jsString(value)
+	for i, attr := range n.attrs {
+		if attr.ns == namespace && attrLocalName(attr.name) == local {
+			n.attrs[i].name = name
+			n.attrs[i].value = str
+			return
+		}
+	}
+	n.attrs = append(n.attrs, memAttr{
+		name:  name,
+		value: str,
+		ns:    namespace,
+	})
+}
+
+func (n *MemNode) RemoveAttributeNS(namespace string, localName string) {
+	for i, attr := range n.attrs {
+		if attr.ns == namespace && attrLocalName(attr.name) == localName {
+			n.attrs = append(n.attrs[:i], n.attrs[i+1:]...)
+			return
+		}
+	}
+}
+
+func attrLocalName(name string) string {
+	if _, local, ok := strings.Cut(name, ":"); ok {
+		return local
+	}
+	return name
+}
+
+func (n *MemNode) GetProperty(name string) any {
+	switch name {
+	case "id":
+		v, _ := n.GetAttribute("id")
+		return v
+	case "className":
+		v, _ := n.GetAttribute("class")
+		return v
+	case "tagName", "nodeName":
+		switch n.kind {
+		case memElement:
+			if !n.isHTML() {
+				return n.tag
+			}
+			return strings.ToUpper(n.tag)
+		case memText:
+			return "#text"
+		case memFragment:
+			return "#document-fragment"
+		}
+		return n.tag
+	case "textContent":
+		return n.TextContent()
+	case "innerHTML":
+		return n.InnerHTML()
+	case "namespaceURI":
+		if n.kind == memElement {
+			return n.ns
+		}
+		return nil
+	}
+	if v, ok := n.props[name]; ok {
+		return v
+	}
+	// default values from attributes
+	switch name {
+	case "value", "type":
+		if v, ok := n.GetAttribute(name); ok {
+			return v
+		}
+	case "checked", "selected", "multiple", "disabled":
+		_, ok := n.GetAttribute(name)
+		return ok
+	case "contentEditable":
+		v, ok := n.GetAttribute("contenteditable")
+		switch {
+		case !ok:
+			return "inherit"
+		case v == "" || strings.EqualFold(v, "true"):
+			return "true"
+		case strings.EqualFold(v, "plaintext-only"):
+			return "plaintext-only"
+		case strings.EqualFold(v, "false"):
+			return "false"
+		}
+		return "inherit"
+	}
+	return nil
+}
+
+func (n *MemNode) SetProperty(name string, value any) {
+	switch name {
+	case "id":
+		n.SetAttribute("id", value)
+		return
+	case "className":
+		n.SetAttribute("class", value)
+		return
+	case "style":
+		n.SetStyleText(jsString(value))
+		return
+	case "textContent":
+		for _, child := range n.children {
+			child.parent = nil
+		}
+		n.children = nil
+		if text := jsString(value); text != "" {
+			n.AppendChild(n.dom.CreateTextNode(text))
+		}
+		return
+	case "innerHTML":
+		n.setInnerHTML(jsString(value))
+		return
+	case "value":
+		// string property, null resets to empty
+		if value == nil {
+			value = ""
+		} else {
+			value = jsString(value)
+		}
+	case "checked", "selected", "multiple", "disabled":
+		// boolean properties
+		value = value != nil && value != false && value != "" && value != 0
+	}
+	if n.props == nil {
+		n.props = make(map[string]any)
+	}
+	n.props[name] = value
+}
+
+func (n *MemNode) DeleteProperty(name string) {
+	delete(n.props, name)
+}
+
+// classes and styles
+
+func (n *MemNode) classList() []string {
+	v, _ := n.GetAttribute("class")
+	return strings.Fields(v)
+}
+
+func (n *MemNode) AddClass(name string) {
+	list := n.classList()
+	for _, c := range list {
+		if c == name {
+			return
+		}
+	}
+	n.setAttr("class", strings.Join(append(list, name), " "))
+}
+
+func (n *MemNode) RemoveClass(name string) {
+	if _, ok := n.GetAttribute("class"); !ok {
+		return
+	}
+	var list []string
+	for _, c := range n.classList() {
+		if c != name {
+			list = append(list, c)
+		}
+	}
+	n.setAttr("class", strings.Join(list, " "))
+}
+
+// style attribute is synchronized lazily, like browsers do
+func (n *MemNode) syncStyle() {
+	if !n.styleDirty {
+		return
+	}
+	n.styleDirty = false
+	text := styleText(n.styles)
+	for i, attr := range n.attrs {
+		if attr.name == "style" {
+			n.attrs[i].value = text
+			return
+		}
+	}
+	if len(n.styles) > 0 {
+		n.attrs = append(n.attrs, memAttr{
+			name:  "style",
+			value: text,
+		})
+	}
+}
+
+func (n *MemNode) SetStyleText(text string) {
+	n.styles = parseStyleText(text)
+	n.styleDirty = true
+}
+
+func (n *MemNode) SetStyle(name string, value string) {
+	if value == "" {
+		n.RemoveStyle(name)
+		return
+	}
+	n.styleDirty = true
+	for i, item := range n.styles {
+		if item.name == name {
+			n.styles[i].value = value
+			return
+		}
+	}
+	n.styles = append(n.styles, memAttr{
+		name:  name,
+		value: value,
+	})
+}
+
+func (n *MemNode) RemoveStyle(name string) {
+	for i, item := range n.styles {
+		if item.name == name {
+			n.styles = append(n.styles[:i], n.styles[i+1:]...)
+			n.styleDirty = true
+			return
+		}
+	}
+}
+
+// GetStyle returns the inline style property value
+func (n *MemNode) GetStyle(name string) string {
+	for _, item := range n.styles {
+		if item.name == name {
+			return item.value
+		}
+	}
+	return ""
+}
+
+func styleText(styles []memAttr) string {
+	var b strings.Builder
+	for i, item := range styles {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(item.name)
+		b.WriteString(": ")
+		b.WriteString(item.value)
+		b.WriteString(";")
+	}
+	return b.String()
+}
+
+func parseStyleText(text string) (ret []memAttr) {
+	for _, decl := range strings.Split(text, ";") {
+		name, value, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		if name == "" || value == "" {
+			continue
+		}
+		ret = append(ret, memAttr{
+			name:  name,
+			value: value,
+		})
+	}
+	return
+}
+
+// jsString converts value to string as javascript String() does
+func jsString(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(value), 'f', -1, 32)
+	}
+	return sp("%v", value)
+}
+
+// events
+
+func (n *MemNode) AddEventListener(event string, fn func(DOMEvent), options ListenerOptions) (remove func()) {
+	listener := &memListener{
+		event:   event,
+		fn:      fn,
+		capture: options.Capture,
+		passive: options.Passive,
+	}
+	n.listeners = append(n.listeners, listener)
+	return func() {
+		for i, l := range n.listeners {
+			if l == listener {
+				n.listeners = append(n.listeners[:i], n.listeners[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// DispatchEvent dispatches ev to n with capturing and bubbling phases.
+// It returns false if the event is canceled
+func (n *MemNode) DispatchEvent(ev *MemEvent) bool {
+	ev.target = n
+	var path []*MemNode
+	for node := n.parent; node != nil; node = node.parent {
+		path = append(path, node)
+	}
+	if n.isConnected() {
+		path = append(path, n.dom.window)
+	}
+
+	// capture
+	for i := len(path) - 1; i >= 0 && !ev.stopped; i-- {
+		path[i].invoke(ev, true)
+	}
+
+	// target
+	if !ev.stopped {
+		n.invoke(ev, true)
+		n.invoke(ev, false)
+	}
+
+	// bubble
+	if ev.bubbles {
+		for _, node := range path {
+			if ev.stopped {
+				break
+			}
+			node.invoke(ev, false)
+		}
+	}
+
+	return !ev.defaultPrevented
+}
+
+func (n *MemNode) invoke(ev *MemEvent, capture bool) {
+	listeners := append(n.listeners[:0:0], n.listeners...)
+	for _, l := range listeners {
+		if ev.stoppedNow {
+			return
+		}
+		if l.event != ev.typ || l.capture != capture {
+			continue
+		}
+		ev.passive = l.passive
+		l.fn(ev)
+		ev.passive = false
+	}
+}
+
+// Click dispatches a bubbling click event
+func (n *MemNode) Click() {
+	n.DispatchEvent(NewMemEvent("click", true))
+}
+
+// Focus makes n the active element, dispatching focus events synchronously like browsers
+func (n *MemNode) Focus() {
+	if n.kind != memElement || !n.isConnected() || n.dom.active == n {
+		return
+	}
+	if last := n.dom.active; last != nil && last.isConnected() {
+		n.dom.active = nil
+		last.DispatchEvent(NewMemEvent("blur", false))
+		last.DispatchEvent(NewMemEvent("focusout", true))
+	}
+	n.dom.active = n
+	n.DispatchEvent(NewMemEvent("focus", false))
+	n.DispatchEvent(NewMemEvent("focusin", true))
+}
+
+func (n *MemNode) HasScrollBar() bool {
+	return false
+}
+
+func (n *MemNode) Reflow() {
+}
+
+// MemEvent is an event of MemDOM
+type MemEvent struct {
+	typ              string
+	bubbles          bool
+	target           *MemNode
+	stopped          bool
+	stoppedNow       bool
+	defaultPrevented bool
+	passive          bool
+	props            map[string]any
+}
+
+var _ DOMEvent = new(MemEvent)
+
+func NewMemEvent(typ string, bubbles bool) *MemEvent {
+	return &MemEvent{
+		typ:     typ,
+		bubbles: bubbles,
+	}
+}
+
+func (e *MemEvent) Type() string {
+	return e.typ
+}
+
+func (e *MemEvent) Bubbles() bool {
+	return e.bubbles
+}
+
+func (e *MemEvent) Target() DOMNode {
+	if e.target == nil {
+		return nil
+	}
+	return e.target
+}
+
+func (e *MemEvent) Get(name string) any {
+	return e.props[name]
+}
+
+// Set sets the property of the event, like key or clientX
+func (e *MemEvent) Set(name string, value any) *MemEvent {
+	if e.props == nil {
+		e.props = make(map[string]any)
+	}
+	e.props[name] = value
+	return e
+}
+
+func (e *MemEvent) StopPropagation() {
+	e.stopped = true
+}
+
+func (e *MemEvent) StopImmediatePropagation() {
+	e.stopped = true
+	e.stoppedNow = true
+}
+
+func (e *MemEvent) PreventDefault() {
+	// no effect in passive listeners
+	if e.passive {
+		return
+	}
+	e.defaultPrevented = true
+}
+
+func (e *MemEvent) DefaultPrevented() bool {
+	return e.defaultPrevented
+}
@@ -0,0 +1,67 @@
+package domui
+
+import "testing"
+
+func TestMemDOMHTML(t *testing.T) {
+	dom := NewMemDOM()
+	div := dom.CreateElement("DIV").(*MemNode)
+	div.SetStyle("color", "red")
+	div.AddClass("foo")
+	div.SetAttribute("title", `a "b" & c`)
+	div.AppendChild(dom.CreateTextNode("<1 & 2>"))
+	img := dom.CreateElement("img")
+	img.SetAttribute("alt", 42)
+	div.AppendChild(img)
+	script := dom.CreateElement("script")
+	script.AppendChild(dom.CreateTextNode("a < b"))
+	div.AppendChild(script)
+
+	html := div.OuterHTML()
+	if html != `<div class="foo" title="a &quot;b&quot; &amp; c" style="color: red;">&lt;1 &amp; 2&gt;<img alt="42"><script>a < b</script></div>` {
+		t.Fatalf("got %s", html)
+	}
+
+	div.RemoveStyle("color")
+	div.RemoveClass("foo")
+	html = div.OuterHTML()
+	if html != `<div class="" title="a &quot;b&quot; &amp; c" style="">&lt;1 &amp; 2&gt;<img alt="42"><script>a < b</script></div>` {
+		t.Fatalf("got %s", html)
+	}
+}
+
+func TestMemDOMEvent(t *testing.T) {
+	dom := NewMemDOM()
+	outer := dom.CreateElement("div").(*MemNode)
+	inner := dom.CreateElement("div").(*MemNode)
+	outer.AppendChild(inner)
+	dom.Body().AppendChild(outer)
+
+	var seq []string
+	outer.AddEventListener("click", func(DOMEvent) {
+		seq = append(seq, "outer capture")
+	}, ListenerOptions{Capture: true})
+	outer.AddEventListener("click", func(DOMEvent) {
+		seq = append(seq, "outer bubble")
+	}, ListenerOptions{})
+	remove := inner.AddEventListener("click", func(ev DOMEvent) {
+		seq = append(seq, "inner")
+		if !ev.Target().Equal(inner) {
+			t.Fatal()
+		}
+	}, ListenerOptions{})
+
+	inner.Click()
+	if len(seq) != 3 ||
+		seq[0] != "outer capture" ||
+		seq[1] != "inner" ||
+		seq[2] != "outer bubble" {
+		t.Fatalf("got %v", seq)
+	}
+
+	seq = seq[:0]
+	remove()
+	inner.DispatchEvent(NewMemEvent("click", false))
+	if len(seq) != 1 || seq[0] != "outer capture" {
+		t.Fatalf("got %v", seq)
+	}
+}
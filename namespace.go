@@ -0,0 +1,86 @@
+package domui
+
+import "strings"
+
+const (
+	HTMLNamespace   = "http://www.w3.org/1999/xhtml"
+	SVGNamespace    = "http://www.w3.org/2000/svg"
+	MathMLNamespace = "http://www.w3.org/1998/Math/MathML"
+	XLinkNamespace  = "http://www.w3.org/1999/xlink"
+	XMLNamespace    = "http://www.w3.org/XML/1998/namespace"
+	XMLNSNamespace  = "http://www.w3.org/2000/xmlns/"
+)
+
+type NSSpec struct {
+	Namespace string
+}
+
+func (_ NSSpec) IsSpec() {}
+
+// NS sets the namespace of the element and its descendants without one.
+// svg and math tags are in SVGNamespace and MathMLNamespace by default
+func NS(namespace string) NSSpec {
+	return NSSpec{
+		Namespace: namespace,
+	}
+}
+
+func tagNamespace(tag string) string {
+	switch strings.ToLower(tag) {
+	case "svg":
+		return SVGNamespace
+	case "math":
+		return MathMLNamespace
+	}
+	return ""
+}
+
+// inheritNamespace sets the namespace of child and its descendants that have no namespace.
+// children of foreignObject stay in the html namespace
+func (n *Node) inheritNamespace(child *Node) {
+	if n.Namespace == "" ||
+		n.Namespace == HTMLNamespace ||
+		strings.EqualFold(n.Text, "foreignObject") {
+		return
+	}
+	if child.Kind == FragmentNode {
+		for _, c := range child.childNodes {
+			n.inheritNamespace(c)
+		}
+		return
+	}
+	if child.Kind != TagNode || child.Namespace != "" {
+		return
+	}
+	child.Namespace = n.Namespace
+	for _, c := range child.childNodes {
+		child.inheritNamespace(c)
+	}
+}
+
+func (n *Node) namespace() string {
+	if n.Namespace == "" {
+		return HTMLNamespace
+	}
+	return n.Namespace
+}
+
+// attrNamespace returns the namespace and local name of prefixed attribute names like xlink:href
+func attrNamespace(name string) (string, string) {
+	prefix, local, ok := strings.Cut(name, ":")
+	if !ok {
+		if name == "xmlns" {
+			return XMLNSNamespace, name
+		}
+		return "", name
+	}
+	switch strings.ToLower(prefix) {
+	case "xlink":
+		return XLinkNamespace, local
+	case "xml":
+		return XMLNamespace, local
+	case "xmlns":
+		return XMLNSNamespace, local
+	}
+	return "", name
+}
@@ -0,0 +1,93 @@
+package domui
+
+import (
+	"testing"
+)
+
+func TestNamespace(t *testing.T) {
+	var (
+		Svg           = Tag("svg")
+		G             = Tag("g")
+		Use           = Tag("use")
+		ForeignObject = Tag("foreignObject")
+	)
+
+	WithTestApp(
+		t,
+		func(app *App) {
+			svg := app.element.ChildNode(0)
+			g := svg.ChildNode(0)
+			use := g.ChildNode(0)
+			foreign := svg.ChildNode(1)
+			for _, node := range []DOMNode{svg, g, use, foreign} {
+				if ns := node.GetProperty("namespaceURI"); ns != SVGNamespace {
+					t.Fatalf("got %v", ns)
+				}
+			}
+			if ns := foreign.ChildNode(0).GetProperty("namespaceURI"); ns != HTMLNamespace {
+				t.Fatalf("got %v", ns)
+			}
+			if ns := app.element.ChildNode(1).GetProperty("namespaceURI"); ns != MathMLNamespace {
+				t.Fatalf("got %v", ns)
+			}
+			if html := app.HTML(); html != `<div><svg viewBox="0 0 10 10"><g><use xlink:href="#a"></use></g><foreignObject><div></div></foreignObject></svg><math><mi>x</mi></math></div>` {
+				t.Fatalf("got %s", html)
+			}
+
+			// namespaced attribute removal
+			app.Update(func() int {
+				return 1
+			})
+			app.Render()
+			if html := app.HTML(); html != `<div><svg viewBox="0 0 10 10"><g><use></use></g><foreignObject><div></div></foreignObject></svg><math><mi>x</mi></math></div>` {
+				t.Fatalf("got %s", html)
+			}
+
+			// namespace change replaces the element
+			app.Update(func() int {
+				return 2
+			})
+			app.Render()
+			if app.element.ChildNode(0).Equal(svg) {
+				t.Fatal()
+			}
+			if ns := app.element.ChildNode(0).GetProperty("namespaceURI"); ns != HTMLNamespace {
+				t.Fatalf("got %v", ns)
+			}
+		},
+		func() int {
+			return 0
+		},
+		func(n int) RootElement {
+			var use *Node
+			if n == 0 {
+				use = Use(Attr("xlink:href")("#a"))
+			} else {
+				use = Use()
+			}
+			var ns Spec
+			if n == 2 {
+				ns = NS(HTMLNamespace)
+			}
+			return Div(
+				Svg(
+					Attr("viewBox")("0 0 10 10"),
+					G(use),
+					ForeignObject(Div()),
+					ns,
+				),
+				Tag("math")(Tag("mi")(Text("x"))),
+			)
+		},
+	)
+}
+
+func TestNSSpec(t *testing.T) {
+	node := Tag("g")(
+		Tag("circle")(),
+		NS(SVGNamespace),
+	)
+	if node.Namespace != SVGNamespace || node.childNodes[0].Namespace != SVGNamespace {
+		t.Fatal()
+	}
+}
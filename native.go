@@ -0,0 +1,33 @@
+//go:build !js || !wasm
+
+package domui
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// macPlatform reports whether the mod shortcut modifier is meta
+var macPlatform = runtime.GOOS == "darwin"
+
+func log(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
+
+func logErr(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
+
+func warn(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
+
+func pr(args ...any) {
+	fmt.Fprintln(os.Stderr, args...)
+}
+
+// nativeNode converts backend values to DOMNode
+func nativeNode(v any) (DOMNode, bool) {
+	return nil, false
+}
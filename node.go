@@ -3,7 +3,6 @@ package domui
 import (
 	"fmt"
 	"reflect"
-	"syscall/js"
 )
 
 type NodeKind uint8
@@ -11,94 +10,138 @@ type NodeKind uint8
 const (
 	TagNode NodeKind = iota
 	TextNode
+	PortalNode
+	FragmentNode
+	RawHTMLNode
 )
 
 type Node struct {
-	Kind       NodeKind
-	Text       string
-	ID         string
-	Style      string
-	Styles     SortedMap // string: string
-	Classes    SortedMap // string: struct{}
-	Attributes SortedMap // string: any
-	Events     map[string][]EventSpec
-	childNodes []*Node
-	Focus      bool
-	args       []reflect.Value
+	Kind         NodeKind
+	Text         string
+	InnerHTML    string
+	Namespace    string
+	ID           string
+	Style        string
+	Styles       SortedMap // string: string
+	Classes      SortedMap // string: struct{}
+	Attributes   SortedMap // string: any
+	Props        SortedMap // string: any
+	Events       map[string][]EventSpec
+	GlobalEvents []EventSpec
+	Shortcuts    []ShortcutSpec
+	Key          any
+	Hooks        []LifecycleSpec
+	Refs         []*Ref
+	Binding      *BindSpec
+	childNodes   []*Node
+	Focus        bool
+	args         []reflect.Value
+	portal       *portalNode
 }
 
 func (_ *Node) IsSpec() {}
 
-func (n *Node) ToElement(scope Scope) (_ js.Value, err error) {
+func (n *Node) ToElement(scope Scope) (_ DOMNode, err error) {
 	defer he(&err)
 
+	var dom DOM
+	var app *App
+	scope.Assign(&dom, &app)
+
 	switch n.Kind {
 
-	case TagNode:
-		element := document.Call(
-			"createElement",
-			n.Text,
-		)
+	case TagNode, RawHTMLNode:
+		var element DOMNode
+		if n.Namespace != "" {
+			element = dom.CreateElementNS(n.Namespace, n.Text)
+		} else {
+			element = dom.CreateElement(n.Text)
+		}
 
-		if len(n.childNodes) > 0 {
-			fragment := document.Call("createDocumentFragment")
+		if n.Kind == RawHTMLNode {
+			element.SetProperty("innerHTML", n.InnerHTML)
+		} else if len(n.childNodes) > 0 {
+			fragment := dom.CreateDocumentFragment()
 			for _, childNode := range n.childNodes {
 				childElement, err := childNode.ToElement(scope)
 				ce(err)
-				fragment.Call("append", childElement)
+				fragment.AppendChild(childElement)
 			}
-			element.Call("appendChild", fragment)
+			element.AppendChild(fragment)
 		}
 
 		if n.ID != "" {
-			element.Set("id", n.ID)
+			element.SetProperty("id", n.ID)
 		}
 
 		if n.Style != "" {
-			element.Set("style", n.Style)
+			element.SetStyleText(n.Style)
 		}
 
 		if len(n.Styles) > 0 {
-			style := element.Get("style")
 			for _, item := range n.Styles {
-				style.Set(item.Key, item.Value)
+				element.SetStyle(item.Key, item.Value.(string))
 			}
 		}
 
 		if len(n.Classes) > 0 {
-			list := element.Get("classList")
 			for _, item := range n.Classes {
-				list.Call("add", item.Key)
+				element.AddClass(item.Key)
 			}
 		}
 
 		if len(n.Attributes) > 0 {
 			for _, item := range n.Attributes {
-				element.Call("setAttribute", item.Key, item.Value)
-				element.Set(item.Key, item.Value)
+				setAttr(element, item.Key, app.resolveLink(item.Value))
 			}
 		}
 
+		if len(n.Props) > 0 {
+			for _, item := range n.Props {
+				element.SetProperty(item.Key, item.Value)
+			}
+		}
+
+		if n.Binding != nil {
+			ce(n.Binding.apply(element))
+		}
+
 		// events
 		if len(n.Events) > 0 {
-			var app *App
-			scope.Assign(&app)
-			setEventSpecs(app.wrapElement, element, n.Events)
+			app.setEventSpecs(element, n.Events)
+		}
+		if len(n.GlobalEvents) > 0 {
+			app.setGlobalEventSpecs(element, n.GlobalEvents)
+		}
+		if len(n.Shortcuts) > 0 {
+			app.setShortcuts(scope, element, n.Shortcuts)
 		}
 
+		// lifecycle
+		app.setHooks(element, n, Mounted)
+
 		if n.Focus {
-			element.Call("focus")
+			app.focus(element)
 		}
 
 		return element, nil
 
 	case TextNode:
-		element := document.Call(
-			"createTextNode",
-			n.Text,
-		)
+		element := dom.CreateTextNode(n.Text)
 		return element, nil
 
+	case PortalNode:
+		return app.mountPortal(scope, n)
+
+	case FragmentNode:
+		fragment := dom.CreateDocumentFragment()
+		for _, childNode := range n.children() {
+			childElement, err := childNode.ToElement(scope)
+			ce(err)
+			fragment.AppendChild(childElement)
+		}
+		return fragment, nil
+
 	}
 
 	panic("bad kind")
@@ -138,7 +181,14 @@ func (node *Node) ApplySpec(spec Spec) {
 	case AttrSpec:
 		node.Attributes.Set(spec.Name, spec.Value)
 
+	case PropSpec:
+		node.Props.Set(spec.Name, spec.Value)
+
 	case EventSpec:
+		if spec.Source != ElementSource {
+			node.GlobalEvents = append(node.GlobalEvents, spec)
+			break
+		}
 		if node.Events == nil {
 			node.Events = make(map[string][]EventSpec)
 		}
@@ -149,9 +199,16 @@ func (node *Node) ApplySpec(spec Spec) {
 
 	case *Node:
 		if spec != nil {
+			node.inheritNamespace(spec)
 			node.childNodes = append(node.childNodes, spec)
 		}
 
+	case NSSpec:
+		node.Namespace = spec.Namespace
+		for _, child := range node.childNodes {
+			node.inheritNamespace(child)
+		}
+
 	case Specs:
 		for _, s := range spec {
 			node.ApplySpec(s)
@@ -160,10 +217,33 @@ func (node *Node) ApplySpec(spec Spec) {
 	case FocusSpec:
 		node.Focus = true
 
+	case KeySpec:
+		node.Key = spec.Value
+
+	case ShortcutSpec:
+		node.Shortcuts = append(node.Shortcuts, spec)
+		node.ApplySpec(spec.eventSpec())
+
+	case LifecycleSpec:
+		node.Hooks = append(node.Hooks, spec)
+
+	case RefSpec:
+		node.Refs = append(node.Refs, spec.Ref)
+
+	case BindSpec:
+		node.Binding = &spec
+		node.ApplySpec(On("input")(spec.onInput))
+
 	case Lazy:
 		s := spec()
 		node.ApplySpec(s)
 
+	case ErrorBoundarySpec:
+		node.applyBoundary(spec)
+
+	case SuspenseSpec:
+		node.applySuspense(spec)
+
 	default:
 		panic(fmt.Errorf("unknown spec: %#v", spec))
 
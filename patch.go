@@ -1,25 +1,29 @@
 package domui
 
 import (
+	"reflect"
+	"slices"
 	"strings"
-	"syscall/js"
 )
 
 func patch(
 	scope Scope,
 	node *Node,
-	lastElement js.Value,
+	lastElement DOMNode,
 	lastNode *Node,
 ) (
-	element js.Value,
+	element DOMNode,
 	err error,
 ) {
 	defer he(&err)
 
-	if lastElement.IsUndefined() {
+	if lastElement == nil {
 		panic("bad last element")
 	}
 
+	var app *App
+	scope.Assign(&app)
+
 	if lastNode != nil && lastNode == node {
 		// same node, same element
 		return lastElement, nil
@@ -27,13 +31,13 @@ func patch(
 
 	replace := func(lastNode *Node) (err error) {
 		defer he(&err)
-		// replace element with newly created one
-		element, err = node.ToElement(scope)
+		// replace elements with newly created ones
+		newElement, err := node.ToElement(scope)
 		ce(err)
-		parent := lastElement.Get("parentNode")
-		parent.Call("insertBefore", element, lastElement)
-		lastElement.Call("remove")
-		unsetEventSpecs(lastElement)
+		element = node.firstElement(newElement)
+		parent := lastElement.ParentNode()
+		parent.InsertBefore(newElement, lastElement)
+		app.removeElements(lastElement, lastNode.span())
 		return nil
 	}
 
@@ -51,8 +55,8 @@ func patch(
 
 	switch node.Kind {
 
-	case TagNode:
-		if node.Text != lastNode.Text {
+	case TagNode, RawHTMLNode:
+		if node.Text != lastNode.Text || node.Namespace != lastNode.Namespace {
 			// not patchable
 			ce(replace(lastNode))
 			return
@@ -61,107 +65,81 @@ func patch(
 	case TextNode:
 		element = lastElement
 		if node.Text != lastNode.Text {
-			element.Set("data", node.Text)
+			element.SetData(node.Text)
 		}
 		return
 
+	case PortalNode:
+		if !samePortalTarget(node.portal.target, lastNode.portal.target) {
+			// not patchable
+			ce(replace(lastNode))
+			return
+		}
+		element = lastElement
+		ce(app.patchPortal(scope, node, element, lastNode))
+		return
+
+	case FragmentNode:
+		end := skipElements(lastElement, lastNode.span())
+		element, err = patchChildren(
+			scope,
+			lastElement.ParentNode(),
+			lastElement,
+			end,
+			node.children(),
+			lastNode.children(),
+		)
+		ce(err)
+		return
+
 	}
 
 	// patchable
 	element = lastElement
 
 	// child nodes
-	elementChildren := element.Get("childNodes")
-	childNodes := node.childNodes
-	lastChildNodes := lastNode.childNodes
-	hasFocus := false
-	for node := document.Get("activeElement"); !node.IsNull() && !node.IsUndefined() && !node.Equal(body); node = node.Get("parentNode") {
-		if node.Equal(element) {
-			hasFocus = true
-			break
-		}
-	}
-	for i, childNode := range childNodes {
-		if i < len(lastChildNodes) {
-
-			childElement := elementChildren.Index(i)
-			hasScrollBar := false
-			if childElement.InstanceOf(htmlElement) {
-				hasScrollBar = hasScrollBar ||
-					childElement.Get("scrollWidth").Int() > childElement.Get("clientWidth").Int()
-				hasScrollBar = hasScrollBar ||
-					childElement.Get("scrollHeight").Int() > childElement.Get("clientHeight").Int()
-			}
-
-			if !hasFocus && !hasScrollBar &&
-				len(lastChildNodes) < len(childNodes) {
-				// insert
-				childElement, err := childNode.ToElement(scope)
-				ce(err)
-				element.Call(
-					"insertBefore",
-					childElement,
-					elementChildren.Index(i),
-				)
-				lastChildNodes = append(
-					lastChildNodes[:i],
-					append([]*Node{nil}, lastChildNodes[i:]...)...,
-				) // insert placeholder
-
-			} else {
-				// replace
-				_, err := patch(
-					scope,
-					childNode,
-					childElement,
-					lastChildNodes[i],
-				)
-				ce(err)
-			}
-
-		} else {
-			// append
-			childElement, err := childNode.ToElement(scope)
-			ce(err)
-			element.Call("appendChild", childElement)
-		}
-
-	}
-	if n := len(lastChildNodes) - len(childNodes); n > 0 {
-		for i := 0; i < n; i++ {
-			lastChild := element.Get("lastChild")
-			lastChild.Call("remove")
-			unsetEventSpecs(lastChild)
+	if node.Kind == RawHTMLNode {
+		if node.InnerHTML != lastNode.InnerHTML {
+			element.SetProperty("innerHTML", node.InnerHTML)
 		}
+	} else {
+		_, err = patchChildren(
+			scope,
+			element,
+			element.ChildNode(0),
+			nil,
+			node.childNodes,
+			lastNode.childNodes,
+		)
+		ce(err)
 	}
 
 	// id
 	if node.ID != lastNode.ID {
 		if node.ID == "" {
-			element.Call("removeAttribute", "id")
-			element.Delete("id")
+			element.RemoveAttribute("id")
+			element.DeleteProperty("id")
 		} else {
-			element.Set("id", node.ID)
-			element.Call("setAttribute", "id", node.ID)
+			element.SetProperty("id", node.ID)
+			element.SetAttribute("id", node.ID)
 		}
 	}
 
 	// style
 	if node.Style != lastNode.Style {
-		element.Set("style", node.Style)
+		element.SetStyleText(node.Style)
 	}
 
 	// styles
-	style := element.Get("style")
 	// must do removing before adding, since different attributes may affect the same style
 	// for example, adding `padding: 1px` then removing `padding-bottom` results to `1px 1px 0 1px` wrongly
 	for _, item := range lastNode.Styles {
 		if node.Styles != nil {
 			if _, ok := node.Styles.Get(item.Key); !ok {
-				style.Set(item.Key, nil)
+				element.RemoveStyle(item.Key)
 			}
 		} else {
-			style.Set(item.Key, nil)
+			element.RemoveStyle(item.Key)
 		}
 	}
 	for _, item := range node.Styles {
@@ -174,84 +152,215 @@ func patch(
 				if strings.HasSuffix(key, "|reset") {
 					// reset
 					key = strings.TrimSuffix(key, "|reset")
-					style.Set(key, nil)
-					element.Get("offsetHeight") // trigger reflow
+					element.RemoveStyle(key)
+					element.Reflow()
 				}
-				style.Set(key, item.Value)
+				element.SetStyle(key, item.Value.(string))
 			}
 		} else {
-			style.Set(item.Key, item.Value)
+			element.SetStyle(item.Key, item.Value.(string))
 		}
 	}
 
 	if node.Style == "" && len(node.Styles) == 0 {
-		element.Call("removeAttribute", "style")
-		element.Delete("style")
+		element.RemoveAttribute("style")
+		element.DeleteProperty("style")
 	}
 
 	// classes
-	list := element.Get("classList")
 	if len(node.Classes) > 0 {
 		for _, item := range node.Classes {
 			if lastNode.Classes != nil {
 				if _, ok := lastNode.Classes.Get(item.Key); !ok {
-					list.Call("add", item.Key)
+					element.AddClass(item.Key)
 				}
 			} else {
-				list.Call("add", item.Key)
+				element.AddClass(item.Key)
 			}
 		}
 		for _, item := range lastNode.Classes {
 			if node.Classes != nil {
 				if _, ok := node.Classes.Get(item.Key); !ok {
-					list.Call("remove", item.Key)
+					element.RemoveClass(item.Key)
 				}
 			} else {
-				list.Call("remove", item.Key)
+				element.RemoveClass(item.Key)
 			}
 		}
 	} else {
-		element.Call("removeAttribute", "class")
-		element.Delete("class")
+		element.RemoveAttribute("class")
+		element.DeleteProperty("class")
 	}
 
 	// attrs
 	for _, item := range node.Attributes {
 		if lastNode.Attributes != nil {
-			if v, ok := lastNode.Attributes.Get(item.Key); !ok || v != item.Value {
-				element.Call("setAttribute", item.Key, item.Value)
-				element.Set(item.Key, item.Value)
+			// links are set again, since relative ones resolve against the current location
+			_, isLink := item.Value.(linkHref)
+			if v, ok := lastNode.Attributes.Get(item.Key); !ok || isLink || !sameValue(v, item.Value) {
+				setAttr(element, item.Key, app.resolveLink(item.Value))
 			}
 		} else {
-			element.Call("setAttribute", item.Key, item.Value)
-			element.Set(item.Key, item.Value)
+			setAttr(element, item.Key, app.resolveLink(item.Value))
 		}
 	}
 	for _, item := range lastNode.Attributes {
 		if node.Attributes != nil {
 			if _, ok := node.Attributes.Get(item.Key); !ok {
-				element.Call("removeAttribute", item.Key)
-				element.Delete(item.Key)
+				removeAttr(element, item.Key)
+			}
+		} else {
+			removeAttr(element, item.Key)
+		}
+	}
+
+	// props
+	for _, item := range node.Props {
+		if lastNode.Props != nil {
+			if v, ok := lastNode.Props.Get(item.Key); !ok || !sameValue(v, item.Value) {
+				element.SetProperty(item.Key, item.Value)
 			}
 		} else {
-			element.Call("removeAttribute", item.Key)
-			element.Delete(item.Key)
+			element.SetProperty(item.Key, item.Value)
+		}
+	}
+	for _, item := range lastNode.Props {
+		if node.Props != nil {
+			if _, ok := node.Props.Get(item.Key); !ok {
+				// null resets reflected properties like value and checked
+				element.SetProperty(item.Key, nil)
+			}
+		} else {
+			element.SetProperty(item.Key, nil)
 		}
 	}
 
+	// binding
+	if node.Binding != nil {
+		ce(node.Binding.apply(element))
+	}
+
 	// events
 	if len(node.Events) > 0 {
-		var app *App
-		scope.Assign(&app)
-		setEventSpecs(app.wrapElement, element, node.Events)
+		app.setEventSpecs(element, node.Events)
 	} else {
-		unsetEventSpecs(element)
+		app.unsetEventSpecs(element)
+	}
+	app.setGlobalEventSpecs(element, node.GlobalEvents)
+	app.setShortcuts(scope, element, node.Shortcuts)
+
+	// lifecycle
+	for _, ref := range lastNode.Refs {
+		if !slices.Contains(node.Refs, ref) {
+			ref.unset(element)
+		}
 	}
+	app.setHooks(element, node, Updated)
 
 	// focus
 	if node.Focus {
-		element.Call("focus")
+		app.focus(element)
 	}
 
 	return
 }
+
+// patchChildren patches the child nodes of parent from first to end (exclusive), which are rendered from lastChildNodes.
+// returns the first DOM node rendered from childNodes
+func patchChildren(
+	scope Scope,
+	parent DOMNode,
+	first DOMNode,
+	end DOMNode,
+	childNodes []*Node,
+	lastChildNodes []*Node,
+) (
+	ret DOMNode,
+	err error,
+) {
+	defer he(&err)
+
+	if hasKeys(childNodes) || hasKeys(lastChildNodes) {
+		return patchKeyedChildren(scope, parent, first, end, childNodes, lastChildNodes)
+	}
+
+	var app *App
+	var dom DOM
+	scope.Assign(&app, &dom)
+
+	hasFocus := false
+	for node := dom.ActiveElement(); node != nil; node = node.ParentNode() {
+		if node.Equal(parent) {
+			hasFocus = true
+			break
+		}
+	}
+
+	// first DOM node of lastChildNodes[i]
+	cursor := first
+	for i, childNode := range childNodes {
+		var childElement DOMNode
+		if i < len(lastChildNodes) {
+
+			hasScrollBar := cursor.HasScrollBar()
+
+			if !hasFocus && !hasScrollBar &&
+				len(lastChildNodes) < len(childNodes) {
+				// insert
+				newElement, err := childNode.ToElement(scope)
+				ce(err)
+				childElement = childNode.firstElement(newElement)
+				parent.InsertBefore(newElement, cursor)
+				lastChildNodes = append(
+					lastChildNodes[:i:i],
+					append([]*Node{nil}, lastChildNodes[i:]...)...,
+				) // insert placeholder
+
+			} else {
+				// replace
+				next := skipElements(cursor, lastChildNodes[i].span())
+				childElement, err = patch(
+					scope,
+					childNode,
+					cursor,
+					lastChildNodes[i],
+				)
+				ce(err)
+				cursor = next
+			}
+
+		} else {
+			// append
+			newElement, err := childNode.ToElement(scope)
+			ce(err)
+			childElement = childNode.firstElement(newElement)
+			parent.InsertBefore(newElement, end)
+		}
+
+		if i == 0 {
+			ret = childElement
+		}
+	}
+
+	if len(lastChildNodes) > len(childNodes) {
+		for _, lastChildNode := range lastChildNodes[len(childNodes):] {
+			next := skipElements(cursor, lastChildNode.span())
+			app.removeElements(cursor, lastChildNode.span())
+			cursor = next
+		}
+	}
+
+	return ret, nil
+}
+
+// sameValue reports whether a and b are equal, uncomparable values like slices and maps are never the same
+func sameValue(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() {
+		return va.IsValid() == vb.IsValid()
+	}
+	if va.Type() != vb.Type() || !va.Comparable() || !vb.Comparable() {
+		return false
+	}
+	return a == b
+}
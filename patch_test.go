@@ -127,7 +127,7 @@ func TestPatch(t *testing.T) {
 		WithTestApp(
 			t,
 			func(app *App) {
-				app.element.Call("click")
+				app.element.(*MemNode).Click()
 				if m[1] != 1 {
 					t.Fatal()
 				}
@@ -136,7 +136,7 @@ func TestPatch(t *testing.T) {
 					return 2
 				})
 				app.Render()
-				app.element.Call("click")
+				app.element.(*MemNode).Click()
 				if m[1] != 1 {
 					t.Fatal()
 				}
@@ -148,7 +148,7 @@ func TestPatch(t *testing.T) {
 					return 3
 				})
 				app.Render()
-				app.element.Call("click")
+				app.element.(*MemNode).Click()
 				if m[1] != 1 {
 					t.Fatal()
 				}
@@ -163,7 +163,7 @@ func TestPatch(t *testing.T) {
 					return 4
 				})
 				app.Render()
-				app.element.Call("click")
+				app.element.(*MemNode).Click()
 				if m[1] != 1 {
 					t.Fatal()
 				}
@@ -279,7 +279,7 @@ func TestPatchingChildNodes(t *testing.T) {
 				app.scope.Assign(&s)
 				app.Render()
 				app.scope.Assign(&s)
-				text := app.element.Get("innerText").String()
+				text := app.element.(*MemNode).TextContent()
 				if text != s {
 					t.Fatalf("\n%s expected\n%s got", s, text)
 				}
@@ -0,0 +1,137 @@
+package domui
+
+import (
+	"fmt"
+)
+
+type portalNode struct {
+	target  any
+	content *Node
+}
+
+// Portal renders specs into a div container appended to target instead of the parent element.
+// target is a DOMNode, a js.Value, or a CSS selector of the document.
+// Events in the portal propagate to ancestors of the Portal node, and the container is removed with the Portal node
+func Portal(target any, specs ...Spec) *Node {
+	return &Node{
+		Kind: PortalNode,
+		portal: &portalNode{
+			target:  target,
+			content: Tag("div")(specs...),
+		},
+	}
+}
+
+// portal is a rendered portal
+type portal struct {
+	placeholder DOMNode
+	container   DOMNode
+	listeners   map[listenerKey]func()
+}
+
+func resolvePortalTarget(dom DOM, target any) (DOMNode, error) {
+	switch target := target.(type) {
+	case DOMNode:
+		return target, nil
+	case string:
+		if node := dom.QuerySelector(target); node != nil {
+			return node, nil
+		}
+		return nil, fmt.Errorf("portal target not found: %s", target)
+	}
+	if node, ok := nativeNode(target); ok {
+		return node, nil
+	}
+	return nil, fmt.Errorf("bad portal target: %#v", target)
+}
+
+func samePortalTarget(a, b any) bool {
+	switch a := a.(type) {
+	case string:
+		s, ok := b.(string)
+		return ok && s == a
+	}
+	nodeA, okA := a.(DOMNode)
+	if !okA {
+		nodeA, okA = nativeNode(a)
+	}
+	nodeB, okB := b.(DOMNode)
+	if !okB {
+		nodeB, okB = nativeNode(b)
+	}
+	return okA && okB && nodeA.Equal(nodeB)
+}
+
+// mountPortal renders the content of node into its target, returns the placeholder element
+func (a *App) mountPortal(scope Scope, node *Node) (_ DOMNode, err error) {
+	defer he(&err)
+
+	target, err := resolvePortalTarget(a.dom, node.portal.target)
+	ce(err)
+	container, err := node.portal.content.ToElement(scope)
+	ce(err)
+	target.AppendChild(container)
+
+	// empty text node marking the position in the parent
+	placeholder := a.dom.CreateTextNode("")
+	p := &portal{
+		placeholder: placeholder,
+		container:   container,
+		listeners:   make(map[listenerKey]func()),
+	}
+	id := a.ensureElementID(placeholder)
+	containerID := a.ensureElementID(container)
+	a.eventsLock.Lock()
+	a.portals[id] = p
+	a.portalContainers[containerID] = p
+	a.eventsLock.Unlock()
+
+	// delegate events of the container
+	for key := range a.listeners {
+		if key.source == ElementSource {
+			p.listeners[key] = a.addRootListener(container, key)
+		}
+	}
+
+	return placeholder, nil
+}
+
+// patchPortal patches the content of the portal rendered as placeholder
+func (a *App) patchPortal(scope Scope, node *Node, placeholder DOMNode, lastNode *Node) (err error) {
+	defer he(&err)
+	id, _ := elementIDOf(placeholder)
+	a.eventsLock.RLock()
+	p := a.portals[id]
+	a.eventsLock.RUnlock()
+	if p == nil {
+		return fmt.Errorf("bad portal element")
+	}
+	container, err := patch(scope, node.portal.content, p.container, lastNode.portal.content)
+	ce(err)
+	if !container.Equal(p.container) {
+		// replaced, like by a namespace change
+		lastContainerID, _ := elementIDOf(p.container)
+		containerID := a.ensureElementID(container)
+		a.eventsLock.Lock()
+		delete(a.portalContainers, lastContainerID)
+		p.container = container
+		a.portalContainers[containerID] = p
+		a.eventsLock.Unlock()
+		// move the delegating listeners
+		for key, remove := range p.listeners {
+			remove()
+			p.listeners[key] = a.addRootListener(container, key)
+		}
+	}
+	return nil
+}
+
+// releasePortal releases and removes the container of p
+func (a *App) releasePortal(p *portal) {
+	a.releaseElement(p.container)
+	for key, remove := range p.listeners {
+		remove()
+		delete(p.listeners, key)
+	}
+	p.container.Remove()
+}
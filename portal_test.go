@@ -0,0 +1,190 @@
+package domui
+
+import (
+	"testing"
+)
+
+func TestPortal(t *testing.T) {
+	dom := NewMemDOM()
+	modalRoot := dom.CreateElement("div")
+	modalRoot.SetProperty("id", "modal-root")
+	dom.Body().AppendChild(modalRoot)
+	parent := dom.CreateElement("div")
+	dom.Body().AppendChild(parent)
+
+	clicks := make(map[string]int)
+	unmounted := 0
+	app := NewDOMApp(
+		dom,
+		parent,
+		func() int {
+			return 0
+		},
+		func(n int) RootElement {
+			if n == 2 {
+				return Div()
+			}
+			return Div(
+				P(
+					Portal("#modal-root",
+						Classes("modal"),
+						Tag("span")(
+							Text("%d", n),
+							OnClick(func() {
+								clicks["inner"]++
+							}),
+						),
+						OnUnmount(func() {
+							unmounted++
+						}),
+					),
+					OnClick(func() {
+						clicks["outer"]++
+					}),
+				),
+			)
+		},
+	)
+	defer app.Close()
+
+	if html := modalRoot.OuterHTML(); html != `<div id="modal-root"><div class="modal"><span>0</span></div></div>` {
+		t.Fatalf("got %s", html)
+	}
+	if html := app.HTML(); html != `<div><p></p></div>` {
+		t.Fatalf("got %s", html)
+	}
+
+	// events propagate to ancestors of the portal node
+	span := modalRoot.ChildNode(0).ChildNode(0).(*MemNode)
+	span.Click()
+	if clicks["inner"] != 1 || clicks["outer"] != 1 {
+		t.Fatalf("got %v", clicks)
+	}
+
+	// patched in place
+	app.Update(func() int {
+		return 1
+	})
+	app.Render()
+	if html := modalRoot.OuterHTML(); html != `<div id="modal-root"><div class="modal"><span>1</span></div></div>` {
+		t.Fatalf("got %s", html)
+	}
+	if !modalRoot.ChildNode(0).ChildNode(0).Equal(span) {
+		t.Fatal()
+	}
+
+	// removed
+	app.Update(func() int {
+		return 2
+	})
+	app.Render()
+	if modalRoot.NumChildNodes() != 0 {
+		t.Fatalf("got %s", modalRoot.OuterHTML())
+	}
+	if unmounted != 1 {
+		t.Fatal()
+	}
+	span.Click()
+	if clicks["inner"] != 1 || clicks["outer"] != 1 {
+		t.Fatalf("got %v", clicks)
+	}
+}
+
+func TestPortalContainerReplaced(t *testing.T) {
+	dom := NewMemDOM()
+	modalRoot := dom.CreateElement("div")
+	dom.Body().AppendChild(modalRoot)
+	parent := dom.CreateElement("div")
+	dom.Body().AppendChild(parent)
+
+	clicks := 0
+	app := NewDOMApp(
+		dom,
+		parent,
+		func() int {
+			return 0
+		},
+		func(n int) RootElement {
+			return Div(
+				Portal(modalRoot,
+					If(n > 0, NS(SVGNamespace)),
+					Tag("button")(
+						OnClick(func() {
+							clicks++
+						}),
+					),
+				),
+			)
+		},
+	)
+	defer app.Close()
+
+	container := modalRoot.ChildNode(0).(*MemNode)
+	app.Update(func() int {
+		return 1
+	})
+	app.Render()
+	if modalRoot.NumChildNodes() != 1 || modalRoot.ChildNode(0).Equal(container) {
+		t.Fatal("container not replaced")
+	}
+	if len(container.listeners) != 0 {
+		t.Fatal("listeners not moved")
+	}
+	if len(app.portalContainers) != 1 {
+		t.Fatalf("got %v", app.portalContainers)
+	}
+
+	modalRoot.ChildNode(0).ChildNode(0).(*MemNode).Click()
+	if clicks != 1 {
+		t.Fatalf("got %d", clicks)
+	}
+}
+
+func TestNestedPortal(t *testing.T) {
+	clicks := 0
+	WithTestApp(
+		t,
+		func(app *App) {
+			// container in the wrap element
+			var container DOMNode
+			for i := 0; i < app.wrapElement.NumChildNodes(); i++ {
+				if e := app.wrapElement.ChildNode(i); !e.Equal(app.element) {
+					container = e
+				}
+			}
+			container.ChildNode(0).(*MemNode).Click()
+			if clicks != 1 {
+				t.Fatalf("got %d", clicks)
+			}
+		},
+		func(app *App) RootElement {
+			return Div(
+				Portal(app.wrapElement,
+					P(OnClick(func() {
+						clicks++
+					})),
+				),
+			)
+		},
+	)
+}
+
+func TestPortalTargetNotFound(t *testing.T) {
+	var err error
+	WithTestApp(
+		t,
+		func(app *App) {
+			if err == nil {
+				t.Fatal()
+			}
+		},
+		func() OnRenderError {
+			return func(e error) {
+				err = e
+			}
+		},
+		func() RootElement {
+			return Div(Portal("#not-found"))
+		},
+	)
+}
@@ -0,0 +1,156 @@
+package domui
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimit limits the call rate of an event handler
+type RateLimit struct {
+	// throttle if true, debounce if false
+	Throttle bool
+	Wait     time.Duration
+	Edges    RateLimitEdge
+}
+
+// RateLimitEdge specifies whether rate limited handlers are called at the start or the end of the wait
+type RateLimitEdge uint8
+
+const (
+	Leading RateLimitEdge = 1 << iota
+	Trailing
+)
+
+// Debounce calls the handler of spec after no event happened in wait, with the last event.
+// Edges default to Trailing, Leading calls the handler on the first event of a burst instead
+func Debounce(wait time.Duration, spec EventSpec, edges ...RateLimitEdge) EventSpec {
+	spec.RateLimit = &RateLimit{
+		Wait:  wait,
+		Edges: rateLimitEdges(edges, Trailing),
+	}
+	return spec
+}
+
+// Throttle calls the handler of spec at most once in every wait.
+// Edges default to Leading and Trailing, calling on the first event and with the last event of every wait
+func Throttle(wait time.Duration, spec EventSpec, edges ...RateLimitEdge) EventSpec {
+	spec.RateLimit = &RateLimit{
+		Throttle: true,
+		Wait:     wait,
+		Edges:    rateLimitEdges(edges, Leading|Trailing),
+	}
+	return spec
+}
+
+func rateLimitEdges(edges []RateLimitEdge, defaultEdges RateLimitEdge) (ret RateLimitEdge) {
+	if len(edges) == 0 {
+		return defaultEdges
+	}
+	for _, edge := range edges {
+		ret |= edge
+	}
+	return
+}
+
+// Clock schedules rate limited event handlers
+type Clock interface {
+	AfterFunc(d time.Duration, fn func()) (stop func() bool)
+}
+
+type realClock struct{}
+
+func (_ realClock) AfterFunc(d time.Duration, fn func()) func() bool {
+	return time.AfterFunc(d, fn).Stop
+}
+
+func (_ Def) Clock() Clock {
+	return realClock{}
+}
+
+// limiter holds the rate limiting state of a handler of an element across renders
+type limiter struct {
+	sync.Mutex
+	clock   Clock
+	limit   RateLimit
+	stop    func() bool // stops the running wait
+	pending func()      // trailing call
+}
+
+// handle calls or schedules call. run calls in the event callback
+func (l *limiter) handle(call func(), run func(func())) {
+	if l.schedule(call) {
+		run(call)
+	}
+}
+
+// schedule starts or restarts the wait, and reports whether call should be called now
+func (l *limiter) schedule(call func()) (now bool) {
+	l.Lock()
+	defer l.Unlock()
+	waiting := l.stop != nil
+
+	if l.limit.Throttle {
+		if waiting {
+			l.setPending(call)
+			return false
+		}
+		if l.limit.Edges&Leading != 0 {
+			now = true
+		} else {
+			l.setPending(call)
+		}
+		l.stop = l.clock.AfterFunc(l.limit.Wait, l.fire)
+		return
+	}
+
+	// debounce
+	if waiting {
+		l.stop()
+		l.setPending(call)
+	} else if l.limit.Edges&Leading != 0 {
+		now = true
+	} else {
+		l.setPending(call)
+	}
+	l.stop = l.clock.AfterFunc(l.limit.Wait, l.fire)
+	return
+}
+
+func (l *limiter) setPending(call func()) {
+	if l.limit.Edges&Trailing != 0 {
+		l.pending = call
+	}
+}
+
+func (l *limiter) fire() {
+	l.Lock()
+	call := l.pending
+	l.pending = nil
+	if call != nil && l.limit.Throttle {
+		// trailing call starts a new wait
+		l.stop = l.clock.AfterFunc(l.limit.Wait, l.fire)
+	} else {
+		l.stop = nil
+	}
+	l.Unlock()
+	if call != nil {
+		call()
+	}
+}
+
+func sameRateLimit(a, b *RateLimit) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (l *limiter) release() {
+	l.Lock()
+	defer l.Unlock()
+	if l.stop != nil {
+		l.stop()
+		l.stop = nil
+	}
+	l.pending = nil
+}
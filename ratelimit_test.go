@@ -0,0 +1,234 @@
+package domui
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	sync.Mutex
+	now    time.Duration
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at      time.Duration
+	fn      func()
+	stopped bool
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, fn func()) func() bool {
+	c.Lock()
+	defer c.Unlock()
+	timer := &fakeTimer{
+		at: c.now + d,
+		fn: fn,
+	}
+	c.timers = append(c.timers, timer)
+	return func() bool {
+		c.Lock()
+		defer c.Unlock()
+		stopped := timer.stopped
+		timer.stopped = true
+		return !stopped
+	}
+}
+
+// Advance moves the clock forward, calling due timers in order
+func (c *fakeClock) Advance(d time.Duration) {
+	c.Lock()
+	end := c.now + d
+	c.Unlock()
+	for {
+		c.Lock()
+		sort.SliceStable(c.timers, func(i, j int) bool {
+			return c.timers[i].at < c.timers[j].at
+		})
+		if len(c.timers) == 0 || c.timers[0].at > end {
+			c.now = end
+			c.Unlock()
+			return
+		}
+		timer := c.timers[0]
+		c.timers = c.timers[1:]
+		c.now = timer.at
+		fire := !timer.stopped
+		timer.stopped = true
+		c.Unlock()
+		if fire {
+			timer.fn()
+		}
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	clock := new(fakeClock)
+	calls := make(map[string][]string)
+	WithTestApp(
+		t,
+		func(app *App) {
+			input := app.element.ChildNode(0).(*MemNode)
+			event := func(typ string, value string) {
+				input.SetProperty("value", value)
+				input.DispatchEvent(NewMemEvent(typ, true))
+			}
+			expect := func(name string, values ...string) {
+				t.Helper()
+				got := calls[name]
+				if len(got) != len(values) {
+					t.Fatalf("%s: got %v", name, got)
+				}
+				for i, v := range values {
+					if got[i] != v {
+						t.Fatalf("%s: got %v", name, got)
+					}
+				}
+			}
+
+			// debounce
+			event("input", "a")
+			clock.Advance(50 * time.Millisecond)
+			event("input", "ab")
+			// re-render does not reset the state
+			app.Update(func() int {
+				return 1
+			})
+			app.Render()
+			clock.Advance(50 * time.Millisecond)
+			event("input", "abc")
+			clock.Advance(99 * time.Millisecond)
+			expect("debounce")
+			expect("leading", "a")
+			clock.Advance(time.Millisecond)
+			expect("debounce", "abc")
+			expect("leading", "a")
+
+			// throttle
+			event("scroll", "1")
+			expect("throttle", "1")
+			clock.Advance(30 * time.Millisecond)
+			event("scroll", "2")
+			clock.Advance(30 * time.Millisecond)
+			event("scroll", "3")
+			expect("throttle", "1")
+			clock.Advance(40 * time.Millisecond)
+			expect("throttle", "1", "3")
+			event("scroll", "4")
+			clock.Advance(100 * time.Millisecond)
+			expect("throttle", "1", "3", "4")
+			clock.Advance(100 * time.Millisecond)
+			event("scroll", "5")
+			expect("throttle", "1", "3", "4", "5")
+
+			// unmount cancels pending calls
+			event("input", "x")
+			app.Update(func() int {
+				return 2
+			})
+			app.Render()
+			clock.Advance(time.Second)
+			expect("debounce", "abc")
+		},
+		func() Clock {
+			return clock
+		},
+		func() int {
+			return 0
+		},
+		func(n int) RootElement {
+			if n == 2 {
+				return Div()
+			}
+			return Div(
+				Tag("input")(
+					Debounce(100*time.Millisecond, On("input")(func(ev InputEvent) {
+						calls["debounce"] = append(calls["debounce"], ev.Value)
+					})),
+					Debounce(100*time.Millisecond, On("input")(func(ev InputEvent) {
+						calls["leading"] = append(calls["leading"], ev.Value)
+					}), Leading),
+					Throttle(100*time.Millisecond, On("scroll")(func(ev InputEvent) {
+						calls["throttle"] = append(calls["throttle"], ev.Value)
+					})),
+				),
+			)
+		},
+	)
+}
+
+func TestRateLimitChanged(t *testing.T) {
+	clock := new(fakeClock)
+	var calls []string
+	WithTestApp(
+		t,
+		func(app *App) {
+			input := app.element.(*MemNode)
+			event := func(value string) {
+				input.SetProperty("value", value)
+				input.DispatchEvent(NewMemEvent("input", true))
+			}
+			expect := func(values ...string) {
+				t.Helper()
+				if len(calls) != len(values) {
+					t.Fatalf("got %v", calls)
+				}
+				for i, v := range values {
+					if calls[i] != v {
+						t.Fatalf("got %v", calls)
+					}
+				}
+			}
+			render := func(n int) {
+				app.Update(func() int {
+					return n
+				})
+				app.Render()
+			}
+
+			// changed wait drops the pending call
+			event("a")
+			render(1)
+			clock.Advance(100 * time.Millisecond)
+			expect()
+			event("b")
+			clock.Advance(299 * time.Millisecond)
+			expect()
+			clock.Advance(time.Millisecond)
+			expect("b")
+
+			// replaced handler does not take over the pending call
+			event("c")
+			render(2)
+			clock.Advance(time.Second)
+			expect("b")
+			event("d")
+			clock.Advance(300 * time.Millisecond)
+			expect("b", "other d")
+		},
+		func() Clock {
+			return clock
+		},
+		func() int {
+			return 0
+		},
+		func(n int) RootElement {
+			wait := 100 * time.Millisecond
+			if n > 0 {
+				wait = 300 * time.Millisecond
+			}
+			fn := func(ev InputEvent) {
+				calls = append(calls, ev.Value)
+			}
+			if n == 2 {
+				fn = func(ev InputEvent) {
+					calls = append(calls, "other "+ev.Value)
+				}
+			}
+			return Tag("input")(
+				Debounce(wait, On("input")(fn)),
+			)
+		},
+	)
+}
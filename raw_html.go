@@ -0,0 +1,23 @@
+package domui
+
+// RawHTML renders html, sanitized by DefaultSanitizer, as the content of a div element.
+// specs are applied to the div element. The content is replaced only when html changes
+func RawHTML(html string, specs ...Spec) *Node {
+	return TrustedHTML(DefaultSanitizer.Sanitize(html), specs...)
+}
+
+// TrustedHTML is like RawHTML, but html is not sanitized.
+// html must be from a trusted source, or sanitized by a custom Sanitizer
+func TrustedHTML(html string, specs ...Spec) *Node {
+	node := &Node{
+		Kind:      RawHTMLNode,
+		Text:      "div",
+		InnerHTML: html,
+	}
+
+	for _, spec := range specs {
+		node.ApplySpec(spec)
+	}
+
+	return node
+}
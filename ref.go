@@ -0,0 +1,48 @@
+package domui
+
+import "sync"
+
+// Ref holds the live element of the node it is applied to
+type Ref struct {
+	lock    sync.RWMutex
+	element DOMNode
+}
+
+func NewRef() *Ref {
+	return new(Ref)
+}
+
+// Element returns the live element, or nil if not rendered or removed
+func (r *Ref) Element() DOMNode {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.element
+}
+
+func (r *Ref) set(element DOMNode) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.element = element
+}
+
+// unset clears the ref if it still holds element
+func (r *Ref) unset(element DOMNode) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.element != nil && r.element.Equal(element) {
+		r.element = nil
+	}
+}
+
+type RefSpec struct {
+	Ref *Ref
+}
+
+func (_ RefSpec) IsSpec() {}
+
+// WithRef populates ref with the element of the node
+func WithRef(ref *Ref) RefSpec {
+	return RefSpec{
+		Ref: ref,
+	}
+}
@@ -0,0 +1,85 @@
+package domui
+
+import (
+	"testing"
+)
+
+func TestRef(t *testing.T) {
+	type InputRef struct {
+		*Ref
+	}
+	var focused DOMNode
+	WithTestApp(
+		t,
+		func(app *App) {
+			var ref InputRef
+			app.scope.Assign(&ref)
+			input := app.element.ChildNode(0)
+			if !ref.Element().Equal(input) {
+				t.Fatal()
+			}
+
+			// used in handler
+			app.element.ChildNode(1).(*MemNode).Click()
+			if !focused.Equal(input) {
+				t.Fatal()
+			}
+
+			// patched
+			app.Update(func() int {
+				return 1
+			})
+			app.Render()
+			if !ref.Element().Equal(input) {
+				t.Fatal()
+			}
+
+			// replaced
+			app.Update(func() int {
+				return 2
+			})
+			app.Render()
+			if ref.Element().Equal(input) {
+				t.Fatal()
+			}
+			if !ref.Element().Equal(app.element.ChildNode(0)) {
+				t.Fatal()
+			}
+
+			// removed
+			app.Update(func() int {
+				return 3
+			})
+			app.Render()
+			if ref.Element() != nil {
+				t.Fatal()
+			}
+		},
+		func() InputRef {
+			return InputRef{NewRef()}
+		},
+		func() int {
+			return 0
+		},
+		func(ref InputRef, n int) RootElement {
+			if n == 3 {
+				return Div()
+			}
+			tag := "input"
+			if n == 2 {
+				tag = "textarea"
+			}
+			return Div(
+				Tag(tag)(
+					WithRef(ref.Ref),
+				),
+				Tag("button")(
+					OnClick(func() {
+						focused = ref.Element()
+						focused.Focus()
+					}),
+				),
+			)
+		},
+	)
+}
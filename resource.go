@@ -0,0 +1,279 @@
+package domui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type ResourceState uint8
+
+const (
+	// the loader is running
+	Loading ResourceState = iota
+	// the loader returned a value
+	Ready
+	// the loader returned an error, or the load is canceled
+	Failed
+)
+
+func (s ResourceState) String() string {
+	switch s {
+	case Loading:
+		return "loading"
+	case Ready:
+		return "ready"
+	case Failed:
+		return "failed"
+	}
+	return fmt.Sprintf("ResourceState(%d)", s)
+}
+
+// ErrResourceLoading is panicked by Resource.Get while loading, to make the enclosing Suspense render its fallback
+var ErrResourceLoading = errors.New("resource is loading, Get must be called in Suspense")
+
+// Resources loads resources for Load. It is redefined for every render of the App.
+// Outside of an App, like in server side rendering, resources are loaded synchronously
+type Resources struct {
+	app *App
+	gen uint64
+}
+
+func (_ Def) Resources() Resources {
+	return Resources{}
+}
+
+type resourceKey struct {
+	typ reflect.Type
+	key any
+}
+
+type resourceEntry struct {
+	app    *App
+	load   func(context.Context) (any, error)
+	used   uint64 // render generation
+	mu     sync.Mutex
+	state  ResourceState
+	value  any
+	err    error
+	cancel context.CancelFunc
+	serial int // ignores results of superseded loads
+}
+
+// Resource is a value loaded asynchronously
+type Resource[T any] struct {
+	entry *resourceEntry
+}
+
+// Load returns the resource of key, starting load in a goroutine if key is not loaded.
+// The resource is kept while it is loaded by renders of the App. When a render does not load it, it is dropped,
+// and canceled if still loading. key must be comparable, and identifies the resource with T.
+// Depend on Resources in the definition calling Load, so it is called again in every render
+func Load[T any](resources Resources, key any, load func(ctx context.Context) (T, error)) Resource[T] {
+	fn := func(ctx context.Context) (any, error) {
+		return load(ctx)
+	}
+	if resources.app == nil {
+		entry := &resourceEntry{
+			load: fn,
+		}
+		entry.run(context.Background(), 0)
+		return Resource[T]{entry: entry}
+	}
+	return Resource[T]{
+		entry: resources.app.loadResource(
+			resourceKey{
+				typ: reflect.TypeFor[T](),
+				key: key,
+			},
+			fn,
+			resources.gen,
+		),
+	}
+}
+
+func (a *App) loadResource(key resourceKey, load func(context.Context) (any, error), gen uint64) *resourceEntry {
+	a.eventsLock.Lock()
+	entry, ok := a.resources[key]
+	if !ok {
+		entry = &resourceEntry{
+			app:  a,
+			load: load,
+		}
+		a.resources[key] = entry
+	}
+	entry.used = gen
+	a.eventsLock.Unlock()
+	if !ok {
+		entry.start()
+	}
+	return entry
+}
+
+// sweepResources cancels and drops resources not loaded in render generation gen
+func (a *App) sweepResources(gen uint64) {
+	a.eventsLock.Lock()
+	var unused []*resourceEntry
+	for key, entry := range a.resources {
+		if entry.used < gen {
+			unused = append(unused, entry)
+			delete(a.resources, key)
+		}
+	}
+	a.eventsLock.Unlock()
+	for _, entry := range unused {
+		entry.stop()
+	}
+}
+
+func (e *resourceEntry) start() {
+	e.mu.Lock()
+	if e.cancel != nil {
+		e.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.serial++
+	serial := e.serial
+	e.state = Loading
+	e.mu.Unlock()
+
+	go func() {
+		if e.run(ctx, serial) {
+			// render with the result
+			e.app.Update()
+		}
+	}()
+}
+
+// run calls the loader, returns false if the load is superseded
+func (e *resourceEntry) run(ctx context.Context, serial int) bool {
+	value, err := e.call(ctx)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if serial != e.serial {
+		return false
+	}
+	if e.cancel != nil {
+		e.cancel()
+		e.cancel = nil
+	}
+	if err != nil {
+		e.state = Failed
+		e.err = err
+		e.value = nil
+	} else {
+		e.state = Ready
+		e.err = nil
+		e.value = value
+	}
+	return true
+}
+
+func (e *resourceEntry) call(ctx context.Context) (value any, err error) {
+	defer recoverErr(&err)
+	return e.load(ctx)
+}
+
+// stop cancels the running load, returns false if not loading
+func (e *resourceEntry) stop() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.state != Loading {
+		return false
+	}
+	if e.cancel != nil {
+		e.cancel()
+		e.cancel = nil
+	}
+	e.serial++
+	e.state = Failed
+	e.err = context.Canceled
+	e.value = nil
+	return true
+}
+
+// State returns the current state
+func (r Resource[T]) State() ResourceState {
+	r.entry.mu.Lock()
+	defer r.entry.mu.Unlock()
+	return r.entry.state
+}
+
+// Value returns the loaded value, or the error if failed. The zero value and nil are returned while loading
+func (r Resource[T]) Value() (ret T, err error) {
+	r.entry.mu.Lock()
+	defer r.entry.mu.Unlock()
+	if r.entry.value != nil {
+		ret = r.entry.value.(T)
+	}
+	return ret, r.entry.err
+}
+
+// Get returns the loaded value. It panics with ErrResourceLoading while loading, which is handled by Suspense,
+// and with the error if failed, which can be handled by ErrorBoundary
+func (r Resource[T]) Get() T {
+	r.entry.mu.Lock()
+	defer r.entry.mu.Unlock()
+	switch r.entry.state {
+	case Loading:
+		panic(ErrResourceLoading)
+	case Failed:
+		panic(r.entry.err)
+	}
+	value, _ := r.entry.value.(T)
+	return value
+}
+
+// Refetch cancels the running load and loads again
+func (r Resource[T]) Refetch() {
+	if r.entry.app == nil {
+		// loaded synchronously without App, like in server side rendering
+		r.entry.mu.Lock()
+		r.entry.serial++
+		serial := r.entry.serial
+		r.entry.mu.Unlock()
+		r.entry.run(context.Background(), serial)
+		return
+	}
+	r.entry.start()
+	r.entry.app.Update()
+}
+
+// Cancel cancels the running load, making the resource Failed with context.Canceled
+func (r Resource[T]) Cancel() {
+	if r.entry.stop() && r.entry.app != nil {
+		r.entry.app.Update()
+	}
+}
+
+type SuspenseSpec struct {
+	Fallback Spec
+	Child    Spec
+}
+
+func (_ SuspenseSpec) IsSpec() {}
+
+// Suspense applies child, or fallback if any resource is loading when applying child.
+// Resources must be read with Resource.Get in a Lazy child, to be loading when applying it
+func Suspense(fallback Spec, child Spec) SuspenseSpec {
+	return SuspenseSpec{
+		Fallback: fallback,
+		Child:    child,
+	}
+}
+
+func (node *Node) applySuspense(spec SuspenseSpec) {
+	// apply to a copy, so a suspended child leaves no partial state
+	n := node.clone()
+	if err := n.tryApplySpec(spec.Child); err != nil {
+		if errors.Is(err, ErrResourceLoading) {
+			node.ApplySpec(spec.Fallback)
+			return
+		}
+		panic(err)
+	}
+	*node = *n
+}
@@ -0,0 +1,173 @@
+package domui
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestResource(t *testing.T) {
+	type UserID int
+
+	var l sync.Mutex
+	results := make(map[UserID]chan string)
+	loads := make(map[UserID]int)
+	result := func(id UserID) chan string {
+		l.Lock()
+		defer l.Unlock()
+		if _, ok := results[id]; !ok {
+			results[id] = make(chan string)
+		}
+		return results[id]
+	}
+	canceled := make(chan UserID, 8)
+
+	var last Resource[string]
+
+	WithTestApp(
+		t,
+		func(app *App) {
+			dom := app.dom.(*MemDOM)
+			expect := func(html string) {
+				t.Helper()
+				waitFor(t, func() bool {
+					dom.Frame()
+					app.scopeLock.Lock()
+					defer app.scopeLock.Unlock()
+					return app.HTML() == html
+				})
+			}
+			setID := func(id UserID) {
+				app.Update(func() UserID {
+					return id
+				})
+			}
+
+			expect(`<div><p>loading 1</p></div>`)
+			result(1) <- "foo"
+			expect(`<div><p>user foo</p></div>`)
+			if last.State() != Ready {
+				t.Fatal()
+			}
+
+			// dropped and canceled
+			setID(2)
+			expect(`<div><p>loading 2</p></div>`)
+			setID(3)
+			expect(`<div><p>loading 3</p></div>`)
+			if id := <-canceled; id != 2 {
+				t.Fatalf("got %d", id)
+			}
+
+			// failed
+			result(3) <- "error"
+			expect(`<div><p>error: failed</p></div>`)
+			if _, err := last.Value(); err == nil || last.State() != Failed {
+				t.Fatal()
+			}
+
+			// refetch
+			last.Refetch()
+			expect(`<div><p>loading 3</p></div>`)
+			result(3) <- "bar"
+			expect(`<div><p>user bar</p></div>`)
+			l.Lock()
+			if loads[3] != 2 {
+				t.Fatalf("got %d", loads[3])
+			}
+			l.Unlock()
+
+			// cancel
+			setID(4)
+			expect(`<div><p>loading 4</p></div>`)
+			last.Cancel()
+			expect(`<div><p>error: context canceled</p></div>`)
+			if id := <-canceled; id != 4 {
+				t.Fatalf("got %d", id)
+			}
+		},
+		func() UserID {
+			return 1
+		},
+		func(resources Resources, id UserID) Resource[string] {
+			return Load(resources, id, func(ctx context.Context) (string, error) {
+				l.Lock()
+				loads[id]++
+				l.Unlock()
+				select {
+				case s := <-result(id):
+					if s == "error" {
+						return "", errors.New("failed")
+					}
+					return s, nil
+				case <-ctx.Done():
+					canceled <- id
+					return "", ctx.Err()
+				}
+			})
+		},
+		func(id UserID, user Resource[string]) RootElement {
+			last = user
+			return Div(
+				ErrorBoundary(
+					func(err error) Spec {
+						return P(Text("error: %v", err))
+					},
+					Suspense(
+						P(Text("loading %d", id)),
+						Lazy(func() Spec {
+							return P(Text("user %s", user.Get()))
+						}),
+					),
+				),
+			)
+		},
+	)
+}
+
+func TestResourceRenderToString(t *testing.T) {
+	var loads atomic.Int32
+	var last Resource[int]
+	html, err := RenderToString(
+		func(resources Resources) Resource[int] {
+			return Load(resources, "answer", func(ctx context.Context) (int, error) {
+				loads.Add(1)
+				return 42, nil
+			})
+		},
+		func(answer Resource[int]) RootElement {
+			last = answer
+			return Suspense(
+				P(Text("loading")),
+				Lazy(func() Spec {
+					return P(Text("%d", answer.Get()))
+				}),
+			)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if html != `<p>42</p>` {
+		t.Fatalf("got %s", html)
+	}
+
+	// refetched synchronously
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			last.Refetch()
+		}()
+	}
+	wg.Wait()
+	if n := loads.Load(); n != 5 {
+		t.Fatalf("got %d", n)
+	}
+	if v, err := last.Value(); err != nil || v != 42 {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,270 @@
+package domui
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Location is the current path of the App, with query and fragment.
+// In the browser, it is synchronized with the History. If defined, the initial Location is the definition instead of the History location
+type Location string
+
+func (_ Def) Location() Location {
+	return "/"
+}
+
+// RouteDef is an entry of the route table
+type RouteDef struct {
+	// Name identifies the route in Route
+	Name string
+	// Pattern is a path like /users/:id, relative to the parent route.
+	// :name matches a path segment as param name, and a trailing * matches the rest of the path as param "*"
+	Pattern string
+	// Children are matched against the rest of the path.
+	// a route with children matches without any child only if there is no rest
+	Children Routes
+}
+
+// Routes is the route table. Earlier routes take precedence
+type Routes []RouteDef
+
+func (_ Def) Routes() Routes {
+	return nil
+}
+
+// Route is the result of matching the Location against Routes
+type Route struct {
+	Location Location
+	Path     string
+	Query    url.Values
+	Fragment string
+	// Matches are the matched routes, from the outermost to the innermost. empty if no route matches
+	Matches []RouteMatch
+	// Params are the path params of all matched routes
+	Params map[string]string
+}
+
+// RouteMatch is a matched RouteDef
+type RouteMatch struct {
+	Name string
+	// Pattern is the full pattern, joined with patterns of parent routes
+	Pattern string
+}
+
+func (_ Def) Route(routes Routes, location Location) Route {
+	return routes.Match(location)
+}
+
+// Found reports whether any route matches
+func (r Route) Found() bool {
+	return len(r.Matches) > 0
+}
+
+// Name returns the name of the innermost matched route
+func (r Route) Name() string {
+	if len(r.Matches) == 0 {
+		return ""
+	}
+	return r.Matches[len(r.Matches)-1].Name
+}
+
+// Is reports whether the route named name is matched, at any level
+func (r Route) Is(name string) bool {
+	for _, m := range r.Matches {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Match matches location against the routes
+func (routes Routes) Match(location Location) Route {
+	route := Route{
+		Location: location,
+		Path:     string(location),
+		Query:    url.Values{},
+		Params:   make(map[string]string),
+	}
+	// segments are unescaped after splitting, for escaped slashes in params
+	escapedPath := string(location)
+	if u, err := url.Parse(string(location)); err == nil {
+		route.Path = u.Path
+		route.Query = u.Query()
+		route.Fragment = u.Fragment
+		escapedPath = u.EscapedPath()
+	}
+	route.Matches, _ = routes.match(pathSegments(escapedPath), "", route.Params)
+	return route
+}
+
+func pathSegments(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func (routes Routes) match(segments []string, parentPattern string, params map[string]string) ([]RouteMatch, bool) {
+	for _, def := range routes {
+		matched := make(map[string]string)
+		rest, ok := matchPattern(def.Pattern, segments, matched)
+		if !ok {
+			continue
+		}
+		m := RouteMatch{
+			Name:    def.Name,
+			Pattern: strings.TrimSuffix(parentPattern, "/") + "/" + strings.Trim(def.Pattern, "/"),
+		}
+		var matches []RouteMatch
+		if len(def.Children) > 0 {
+			matches, _ = def.Children.match(rest, m.Pattern, matched)
+		}
+		if matches == nil && len(rest) > 0 {
+			continue
+		}
+		for k, v := range matched {
+			params[k] = v
+		}
+		return append([]RouteMatch{m}, matches...), true
+	}
+	return nil, false
+}
+
+// matchPattern matches the leading segments against pattern, returns the rest
+func matchPattern(pattern string, segments []string, params map[string]string) ([]string, bool) {
+	for _, p := range pathSegments(pattern) {
+		if p == "*" {
+			params["*"] = strings.Join(segments, "/")
+			return nil, true
+		}
+		if len(segments) == 0 {
+			return nil, false
+		}
+		segment, err := url.PathUnescape(segments[0])
+		if err != nil {
+			segment = segments[0]
+		}
+		if name, ok := strings.CutPrefix(p, ":"); ok {
+			params[name] = segment
+		} else if p != segment {
+			return nil, false
+		}
+		segments = segments[1:]
+	}
+	return segments, true
+}
+
+// RouteParam parses the path param name of route as T.
+// T can be a string, bool, integer or float type, or implement encoding.TextUnmarshaler
+func RouteParam[T any](route Route, name string) (ret T, err error) {
+	s, ok := route.Params[name]
+	if !ok {
+		return ret, fmt.Errorf("no route param: %s", name)
+	}
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("bad route param %s: %w", name, err)
+		}
+	}()
+
+	if u, ok := any(&ret).(encoding.TextUnmarshaler); ok {
+		return ret, u.UnmarshalText([]byte(s))
+	}
+	return ret, setFromString(reflect.ValueOf(&ret).Elem(), s)
+}
+
+// Navigate changes the location of the App, adding a history entry
+type Navigate func(location string)
+
+// Navigate pushes location to the History, and renders for the new Location.
+// location can be relative to the current one
+func (a *App) Navigate(location string) {
+	a.history.Push(location)
+	a.setLocation(Location(a.history.Location()))
+}
+
+// Redirect is like Navigate, but replaces the current history entry
+func (a *App) Redirect(location string) {
+	a.history.Replace(location)
+	a.setLocation(Location(a.history.Location()))
+}
+
+// setLocation updates the Location if changed
+func (a *App) setLocation(location Location) {
+	a.scopeLock.Lock()
+	var current Location
+	a.scope.Assign(&current)
+	a.scopeLock.Unlock()
+	if location == current {
+		return
+	}
+	a.Update(func() Location {
+		return location
+	})
+}
+
+// Link makes the element navigate to location when clicked, instead of loading the page.
+// It also sets the href attribute, so clicks with modifier keys or middle button, which are not intercepted,
+// open the location in new tabs or windows
+func Link(location string) Specs {
+	return Specs{
+		Attr("href")(linkHref(location)),
+		EventSpec{
+			Event: "click",
+			Func: func(navigate Navigate) {
+				navigate(location)
+			},
+			Modifiers: PreventDefault,
+			Filter:    plainClick,
+		},
+	}
+}
+
+// linkHref is the href of Link, rendered by History.Href
+type linkHref string
+
+// resolveLink returns the href of value if it is set by Link
+func (a *App) resolveLink(value any) any {
+	if href, ok := value.(linkHref); ok && a.history != nil {
+		return a.history.Href(string(href))
+	}
+	return value
+}
+
+var locationType = reflect.TypeOf(Location(""))
+
+// definesLocation reports whether defs provide Location, which then takes precedence over the History
+func definesLocation(defs []any) bool {
+	for _, def := range defs {
+		t := reflect.TypeOf(def)
+		if t == nil {
+			continue
+		}
+		switch t.Kind() {
+		case reflect.Func:
+			for i := 0; i < t.NumOut(); i++ {
+				if t.Out(i) == locationType {
+					return true
+				}
+			}
+		case reflect.Pointer:
+			if t.Elem() == locationType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func plainClick(ev DOMEvent) bool {
+	return eventFloat(ev, "button") == 0 &&
+		!eventBool(ev, "ctrlKey") &&
+		!eventBool(ev, "metaKey") &&
+		!eventBool(ev, "shiftKey") &&
+		!eventBool(ev, "altKey")
+}
@@ -0,0 +1,279 @@
+package domui
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestRoutesMatch(t *testing.T) {
+	routes := Routes{
+		{Name: "home", Pattern: "/"},
+		{Name: "users", Pattern: "/users", Children: Routes{
+			{Name: "new", Pattern: "new"},
+			{Name: "user", Pattern: ":id", Children: Routes{
+				{Name: "posts", Pattern: "posts/:post"},
+			}},
+		}},
+		{Name: "files", Pattern: "/files/*"},
+	}
+
+	for _, c := range []struct {
+		location string
+		names    []string
+		pattern  string
+		params   map[string]string
+	}{
+		{"/", []string{"home"}, "/", nil},
+		{"", []string{"home"}, "/", nil},
+		{"/users", []string{"users"}, "/users", nil},
+		{"/users/", []string{"users"}, "/users", nil},
+		{"/users/new", []string{"users", "new"}, "/users/new", nil},
+		{"/users/42?tab=info#top", []string{"users", "user"}, "/users/:id", map[string]string{"id": "42"}},
+		{"/users/a%2Fb", []string{"users", "user"}, "/users/:id", map[string]string{"id": "a/b"}},
+		{"/users/42/posts/1", []string{"users", "user", "posts"}, "/users/:id/posts/:post", map[string]string{"id": "42", "post": "1"}},
+		{"/users/42/posts", nil, "", nil},
+		{"/files/a/b.txt", []string{"files"}, "/files/*", map[string]string{"*": "a/b.txt"}},
+		{"/foo", nil, "", nil},
+	} {
+		route := routes.Match(Location(c.location))
+		if len(route.Matches) != len(c.names) {
+			t.Fatalf("%s: got %+v", c.location, route.Matches)
+		}
+		for i, name := range c.names {
+			if route.Matches[i].Name != name || !route.Is(name) {
+				t.Fatalf("%s: got %+v", c.location, route.Matches)
+			}
+		}
+		if route.Found() != (len(c.names) > 0) {
+			t.Fatal()
+		}
+		if route.Found() && route.Matches[len(route.Matches)-1].Pattern != c.pattern {
+			t.Fatalf("%s: got %+v", c.location, route.Matches)
+		}
+		if len(route.Params) != len(c.params) {
+			t.Fatalf("%s: got %+v", c.location, route.Params)
+		}
+		for k, v := range c.params {
+			if route.Params[k] != v {
+				t.Fatalf("%s: got %+v", c.location, route.Params)
+			}
+		}
+	}
+
+	route := routes.Match("/users/42?tab=info#top")
+	if route.Name() != "user" ||
+		route.Path != "/users/42" ||
+		route.Query.Get("tab") != "info" ||
+		route.Fragment != "top" {
+		t.Fatalf("got %+v", route)
+	}
+}
+
+func TestRouteParam(t *testing.T) {
+	route := Route{
+		Params: map[string]string{
+			"id":   "42",
+			"name": "foo",
+			"ok":   "true",
+			"ip":   "127.0.0.1",
+		},
+	}
+	if id, err := RouteParam[int](route, "id"); err != nil || id != 42 {
+		t.Fatal(err)
+	}
+	if id, err := RouteParam[uint8](route, "id"); err != nil || id != 42 {
+		t.Fatal(err)
+	}
+	if f, err := RouteParam[float64](route, "id"); err != nil || f != 42 {
+		t.Fatal(err)
+	}
+	if name, err := RouteParam[string](route, "name"); err != nil || name != "foo" {
+		t.Fatal(err)
+	}
+	if ok, err := RouteParam[bool](route, "ok"); err != nil || !ok {
+		t.Fatal(err)
+	}
+	if ip, err := RouteParam[netip.Addr](route, "ip"); err != nil || ip != netip.MustParseAddr("127.0.0.1") {
+		t.Fatal(err)
+	}
+	if _, err := RouteParam[int](route, "name"); err == nil {
+		t.Fatal()
+	}
+	if _, err := RouteParam[int](route, "foo"); err == nil {
+		t.Fatal()
+	}
+	if _, err := RouteParam[[]int](route, "id"); err == nil {
+		t.Fatal()
+	}
+}
+
+func TestRouter(t *testing.T) {
+	history := NewMemoryHistory("/users/1")
+	WithTestApp(
+		t,
+		func(app *App) {
+			check := func(location string, html string) {
+				t.Helper()
+				app.Render()
+				if got := history.Location(); got != location {
+					t.Fatalf("got %s", got)
+				}
+				if got := app.HTML(); got != html {
+					t.Fatalf("got %s", got)
+				}
+			}
+			check("/users/1", `<div><p>user 1</p><a href="/">home</a><a href="/users/2">next</a></div>`)
+
+			// link
+			next := app.element.ChildNode(2).(*MemNode)
+			if next.DispatchEvent(NewMemEvent("click", true)) {
+				t.Fatal("not prevented")
+			}
+			check("/users/2", `<div><p>user 2</p><a href="/">home</a><a href="/users/3">next</a></div>`)
+
+			// not intercepted
+			home := app.element.ChildNode(1).(*MemNode)
+			if !home.DispatchEvent(NewMemEvent("click", true).Set("ctrlKey", true)) {
+				t.Fatal("prevented")
+			}
+			check("/users/2", `<div><p>user 2</p><a href="/">home</a><a href="/users/3">next</a></div>`)
+			home.Click()
+			check("/", `<div><p>home</p><a href="/">home</a></div>`)
+
+			// back and forward
+			history.Back()
+			check("/users/2", `<div><p>user 2</p><a href="/">home</a><a href="/users/3">next</a></div>`)
+			history.Forward()
+			check("/", `<div><p>home</p><a href="/">home</a></div>`)
+
+			// relative
+			app.Navigate("users/5?tab=posts")
+			check("/users/5?tab=posts", `<div><p>user 5 posts</p><a href="/">home</a><a href="/users/6">next</a></div>`)
+
+			// not found
+			app.Redirect("/foo")
+			check("/foo", `<div><p>not found</p><a href="/">home</a></div>`)
+			history.Back()
+			check("/", `<div><p>home</p><a href="/">home</a></div>`)
+		},
+		func() History {
+			return history
+		},
+		func() Routes {
+			return Routes{
+				{Name: "home", Pattern: "/"},
+				{Name: "user", Pattern: "/users/:id"},
+			}
+		},
+		func(route Route) RootElement {
+			var content Spec
+			switch route.Name() {
+			case "home":
+				content = P(Text("home"))
+			case "user":
+				id, err := RouteParam[int](route, "id")
+				if err != nil {
+					panic(err)
+				}
+				content = Specs{
+					P(
+						Text("user %d", id),
+						If(route.Query.Has("tab"), Text(" %s", route.Query.Get("tab"))),
+					),
+					Tag("a")(Text("home"), Link("/")),
+					Tag("a")(Text("next"), Link(sp("/users/%d", id+1))),
+				}
+			default:
+				content = P(Text("not found"))
+			}
+			return Div(
+				content,
+				If(route.Name() != "user",
+					Tag("a")(Text("home"), Link("/")),
+				),
+			)
+		},
+	)
+}
+
+type hashMemoryHistory struct {
+	*MemoryHistory
+}
+
+func (h hashMemoryHistory) Href(location string) string {
+	return "#" + resolveLocation(h.Location(), location)
+}
+
+func TestLinkHref(t *testing.T) {
+	WithTestApp(
+		t,
+		func(app *App) {
+			if html := app.HTML(); html != `<a href="#/users/1">user</a>` {
+				t.Fatalf("got %s", html)
+			}
+			app.element.(*MemNode).Click()
+			app.Render()
+			if location := app.history.Location(); location != "/users/1" {
+				t.Fatalf("got %s", location)
+			}
+		},
+		func() History {
+			return hashMemoryHistory{NewMemoryHistory("/")}
+		},
+		func() RootElement {
+			return Tag("a")(Text("user"), Link("/users/1"))
+		},
+	)
+}
+
+func TestRelativeLinkHref(t *testing.T) {
+	WithTestApp(
+		t,
+		func(app *App) {
+			if html := app.HTML(); html != `<div><a href="#/a/edit">edit</a><p>/a/</p></div>` {
+				t.Fatalf("got %s", html)
+			}
+			app.Navigate("/b/")
+			app.Render()
+			if html := app.HTML(); html != `<div><a href="#/b/edit">edit</a><p>/b/</p></div>` {
+				t.Fatalf("got %s", html)
+			}
+		},
+		func() History {
+			return hashMemoryHistory{NewMemoryHistory("/a/")}
+		},
+		func(location Location) RootElement {
+			return Div(
+				Tag("a")(Text("edit"), Link("edit")),
+				P(Text("%s", location)),
+			)
+		},
+	)
+}
+
+func TestDefinedLocation(t *testing.T) {
+	history := NewMemoryHistory("/")
+	WithTestApp(
+		t,
+		func(app *App) {
+			if html := app.HTML(); html != `<p>/users/1</p>` {
+				t.Fatalf("got %s", html)
+			}
+			// synchronized after navigation
+			app.Navigate("/users/2")
+			app.Render()
+			if html := app.HTML(); html != `<p>/users/2</p>` {
+				t.Fatalf("got %s", html)
+			}
+		},
+		func() History {
+			return history
+		},
+		func() Location {
+			return "/users/1"
+		},
+		func(location Location) RootElement {
+			return P(Text("%s", location))
+		},
+	)
+}
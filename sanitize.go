@@ -0,0 +1,150 @@
+package domui
+
+import (
+	"strings"
+)
+
+// Sanitizer removes elements, attributes and URLs not in its allowlists from HTML.
+// elements not allowed are removed while keeping their content, except unparsed elements like script and style,
+// and template, object, svg and math elements, which are removed with their content
+type Sanitizer struct {
+	// allowed elements, in lower case
+	Tags map[string]bool
+	// allowed attributes of allowed elements, in lower case
+	Attributes map[string]bool
+	// allowed schemes of URL attributes like href and src, in lower case. relative URLs are always allowed
+	URLSchemes map[string]bool
+}
+
+func setOf(names string) map[string]bool {
+	ret := make(map[string]bool)
+	for _, name := range strings.Fields(names) {
+		ret[name] = true
+	}
+	return ret
+}
+
+// DefaultSanitizer allows formatting, lists, tables, links and images, without styles, ids and event handlers
+var DefaultSanitizer = &Sanitizer{
+	Tags: setOf(`
+		a abbr b bdi bdo blockquote br caption cite code col colgroup dd del details dfn div dl dt em
+		figcaption figure h1 h2 h3 h4 h5 h6 hr i img ins kbd li mark ol p pre q rp rt ruby s samp
+		small span strong sub summary sup table tbody td tfoot th thead time tr u ul var wbr
+	`),
+	Attributes: setOf(`
+		abbr align alt cite class colspan datetime dir height href lang open rel reversed rowspan
+		scope span src start title type valign width
+	`),
+	URLSchemes: setOf(`http https mailto`),
+}
+
+// elements removed with their content
+var droppedElements = map[string]bool{
+	"math": true, "object": true, "svg": true, "template": true,
+}
+
+// attributes with URL values
+var urlAttributes = map[string]bool{
+	"action": true, "background": true, "cite": true, "formaction": true,
+	"href": true, "longdesc": true, "poster": true, "src": true,
+}
+
+// Sanitize returns the allowed parts of html. unclosed elements are closed, and unmatched end tags are removed
+func (s *Sanitizer) Sanitize(html string) string {
+	var b strings.Builder
+	var open []string
+	// name and depth of the element being removed with its content
+	var dropping string
+	depth := 0
+
+	for _, token := range tokenizeHTML(html) {
+
+		if depth > 0 {
+			switch {
+			case token.kind == htmlStartTag && token.data == dropping && !token.selfClosing:
+				depth++
+			case token.kind == htmlEndTag && token.data == dropping:
+				depth--
+			}
+			continue
+		}
+
+		switch token.kind {
+
+		case htmlText:
+			b.WriteString(textEscaper.Replace(token.data))
+
+		case htmlStartTag:
+			if unparsedElements[token.data] || droppedElements[token.data] {
+				if !token.selfClosing || unparsedElements[token.data] {
+					dropping = token.data
+					depth = 1
+				}
+				continue
+			}
+			if !s.Tags[token.data] {
+				continue
+			}
+			b.WriteString("<")
+			b.WriteString(token.data)
+			for _, attr := range token.attrs {
+				if !s.Attributes[attr.name] {
+					continue
+				}
+				if urlAttributes[attr.name] && !s.allowURL(attr.value) {
+					continue
+				}
+				b.WriteString(" ")
+				b.WriteString(attr.name)
+				b.WriteString(`="`)
+				b.WriteString(attrEscaper.Replace(attr.value))
+				b.WriteString(`"`)
+			}
+			b.WriteString(">")
+			if !voidElements[token.data] {
+				open = append(open, token.data)
+			}
+
+		case htmlEndTag:
+			i := len(open) - 1
+			for i >= 0 && open[i] != token.data {
+				i--
+			}
+			if i < 0 {
+				// not opened
+				continue
+			}
+			for j := len(open) - 1; j >= i; j-- {
+				b.WriteString("</")
+				b.WriteString(open[j])
+				b.WriteString(">")
+			}
+			open = open[:i]
+
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</")
+		b.WriteString(open[i])
+		b.WriteString(">")
+	}
+
+	return b.String()
+}
+
+func (s *Sanitizer) allowURL(value string) bool {
+	// browsers ignore whitespaces and control characters in schemes
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, value)
+	i := strings.IndexAny(value, ":/?#")
+	if i < 0 || value[i] != ':' {
+		// relative
+		return true
+	}
+	return s.URLSchemes[strings.ToLower(value[:i])]
+}
@@ -0,0 +1,113 @@
+package domui
+
+import (
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	for _, c := range [][2]string{
+		{`<p>foo <b>bar</b></p>`, `<p>foo <b>bar</b></p>`},
+		{`<P CLASS=a ID="b">foo</P>`, `<p class="a">foo</p>`},
+		{`<script>alert(1)</script>foo`, `foo`},
+		{`<SCRIPT src=x></SCRIPT >foo`, `foo`},
+		{`<style>p{}</style><p>foo`, `<p>foo</p>`},
+		{`<img src=x onerror="alert(1)">`, `<img src="x">`},
+		{`<a href="javascript:alert(1)">foo</a>`, `<a>foo</a>`},
+		{`<a href=" JaVa&#x53;cript&colon;alert(1)">foo</a>`, `<a>foo</a>`},
+		{"<a href=\"java\tscript:alert(1)\">foo</a>", `<a>foo</a>`},
+		{`<a href="https://example.com/?a=1&amp;b=2">foo</a>`, `<a href="https://example.com/?a=1&amp;b=2">foo</a>`},
+		{`<a href="/foo:bar">foo</a>`, `<a href="/foo:bar">foo</a>`},
+		{`<img src="data:image/png;base64,AAAA">`, `<img>`},
+		{`<custom>foo</custom>`, `foo`},
+		{`<svg><script>alert(1)</script><svg></svg>x</svg>foo`, `foo`},
+		{`<template><p>foo</p></template>`, ``},
+		{`<textarea><p>foo</p></textarea>bar`, `bar`},
+		{`<!-- <p>foo</p> -->bar<!doctype html>`, `bar`},
+		{`</div>foo</p>`, `foo`},
+		{`<div><p>foo</div>bar`, `<div><p>foo</p></div>bar`},
+		{`<ul><li>foo`, `<ul><li>foo</li></ul>`},
+		{`a < b && c > d`, `a &lt; b &amp;&amp; c &gt; d`},
+		{`foo &lt;script&gt;`, `foo &lt;script&gt;`},
+		{`<p title='a"b'>foo</p>`, `<p title="a&quot;b">foo</p>`},
+		{`<p title="a" title="b">`, `<p title="a"></p>`},
+		{`<p>foo<br/>bar</p>`, `<p>foo<br>bar</p>`},
+		{`<p title="foo`, ``},
+		{`foo<p`, `foo`},
+	} {
+		if got := DefaultSanitizer.Sanitize(c[0]); got != c[1] {
+			t.Fatalf("%s: got %s, expecting %s", c[0], got, c[1])
+		}
+	}
+}
+
+func TestRawHTML(t *testing.T) {
+	WithTestApp(
+		t,
+		func(app *App) {
+			if html := app.HTML(); html != `<div><div class="content"><p>foo</p></div></div>` {
+				t.Fatalf("got %s", html)
+			}
+			content := app.element.ChildNode(0)
+			p := content.ChildNode(0)
+
+			// not changed
+			app.Update(func() int {
+				return 1
+			})
+			app.Render()
+			if !content.ChildNode(0).Equal(p) {
+				t.Fatal()
+			}
+
+			// changed
+			app.Update(func() string {
+				return `<p>bar</p><script>alert(1)</script>`
+			})
+			app.Render()
+			if html := app.HTML(); html != `<div><div class="content"><p>bar</p></div></div>` {
+				t.Fatalf("got %s", html)
+			}
+			if !app.element.ChildNode(0).Equal(content) {
+				t.Fatal()
+			}
+		},
+		func() string {
+			return `<p onclick="alert(1)">foo</p>`
+		},
+		func() int {
+			return 0
+		},
+		func(html string, _ int) RootElement {
+			return Div(
+				RawHTML(html, Class("content")),
+			)
+		},
+	)
+}
+
+func TestTrustedHTML(t *testing.T) {
+	defs := []any{
+		func() RootElement {
+			return Div(
+				TrustedHTML(`<p style="color: red">foo</p>`),
+				P(Text("bar")),
+			)
+		},
+	}
+	html, err := RenderToString(defs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if html != `<div><div><p style="color: red">foo</p></div><p>bar</p></div>` {
+		t.Fatalf("got %s", html)
+	}
+	WithTestApp(
+		t,
+		func(app *App) {
+			if got := app.HTML(); got != html {
+				t.Fatalf("got %s", got)
+			}
+		},
+		defs...,
+	)
+}
@@ -0,0 +1,299 @@
+package domui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ShortcutSpec handles a key combination pressed in the subtree of the element, or anywhere in the document if global.
+// Matched key events are prevented and stopped, so inner shortcuts override outer and global ones
+type ShortcutSpec struct {
+	// Keys is the normalized key combination, like "ctrl+shift+k"
+	Keys        string
+	Global      bool
+	Description string
+	Func        any
+	combo       keyCombo
+}
+
+func (_ ShortcutSpec) IsSpec() {}
+
+// Shortcut handles keys pressed while the focus is in the element or its descendants.
+//
+// keys is a combination of modifiers and a key joined by "+", like "ctrl+k", "shift+?" or "mod+enter".
+// Modifiers are ctrl, alt, shift and meta, mod is meta on macOS and ctrl on others.
+// Keys are key values or codes of KeyboardEvent, case-insensitive, like "k", "escape", "arrowup" or "f1".
+// Codes are matched only when the key value is not a latin letter or digit, like "!" for shift+1
+// It panics if keys is invalid
+func Shortcut(keys string, fn any) ShortcutSpec {
+	combo, err := parseKeyCombo(keys)
+	if err != nil {
+		panic(err)
+	}
+	return ShortcutSpec{
+		Keys:  combo.String(),
+		Func:  fn,
+		combo: combo,
+	}
+}
+
+// GlobalShortcut handles keys pressed anywhere in the document while the element is mounted
+func GlobalShortcut(keys string, fn any) ShortcutSpec {
+	spec := Shortcut(keys, fn)
+	spec.Global = true
+	return spec
+}
+
+// Describe sets the description of the shortcut, listed by App.Shortcuts
+func (s ShortcutSpec) Describe(description string) ShortcutSpec {
+	s.Description = description
+	return s
+}
+
+func (s ShortcutSpec) eventSpec() EventSpec {
+	on := On
+	if s.Global {
+		on = OnWindow
+	}
+	spec := on("keydown", PreventDefault, StopPropagation)(s.Func)
+	spec.Filter = s.combo.match
+	return spec
+}
+
+type keyCombo struct {
+	ctrl  bool
+	alt   bool
+	shift bool
+	meta  bool
+	key   string
+}
+
+var keyAliases = map[string]string{
+	"esc":    "escape",
+	"return": "enter",
+	"space":  " ",
+	"plus":   "+",
+	"up":     "arrowup",
+	"down":   "arrowdown",
+	"left":   "arrowleft",
+	"right":  "arrowright",
+	"del":    "delete",
+	"ins":    "insert",
+}
+
+func parseKeyCombo(keys string) (combo keyCombo, err error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(keys)), "+")
+	if n := len(parts); n > 1 && parts[n-1] == "" && parts[n-2] == "" {
+		// ends with "++"
+		parts = append(parts[:n-2], "+")
+	}
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return combo, fmt.Errorf("bad shortcut: %q", keys)
+		}
+		if i == len(parts)-1 {
+			if alias, ok := keyAliases[part]; ok {
+				part = alias
+			}
+			combo.key = part
+			break
+		}
+		switch part {
+		case "ctrl", "control":
+			combo.ctrl = true
+		case "alt", "option":
+			combo.alt = true
+		case "shift":
+			combo.shift = true
+		case "meta", "cmd", "command", "super":
+			combo.meta = true
+		case "mod":
+			if macPlatform {
+				combo.meta = true
+			} else {
+				combo.ctrl = true
+			}
+		default:
+			return combo, fmt.Errorf("bad shortcut modifier %q in %q", part, keys)
+		}
+	}
+	return
+}
+
+func (c keyCombo) String() string {
+	var parts []string
+	if c.ctrl {
+		parts = append(parts, "ctrl")
+	}
+	if c.alt {
+		parts = append(parts, "alt")
+	}
+	if c.shift {
+		parts = append(parts, "shift")
+	}
+	if c.meta {
+		parts = append(parts, "meta")
+	}
+	switch c.key {
+	case " ":
+		parts = append(parts, "space")
+	case "+":
+		parts = append(parts, "plus")
+	default:
+		parts = append(parts, c.key)
+	}
+	return strings.Join(parts, "+")
+}
+
+func (c keyCombo) match(ev DOMEvent) bool {
+	if eventBool(ev, "ctrlKey") != c.ctrl ||
+		eventBool(ev, "altKey") != c.alt ||
+		eventBool(ev, "shiftKey") != c.shift ||
+		eventBool(ev, "metaKey") != c.meta {
+		return false
+	}
+	key := strings.ToLower(eventString(ev, "key"))
+	if key == c.key {
+		return true
+	}
+	if len(key) == 1 && (key[0] >= 'a' && key[0] <= 'z' || key[0] >= '0' && key[0] <= '9') {
+		// a letter or digit in the keyboard layout, like ctrl+a on azerty with code KeyQ
+		return false
+	}
+	// modifiers and layouts may change the key value, like shift+1, alt+k on macOS and cyrillic letters
+	code := strings.ToLower(eventString(ev, "code"))
+	for _, prefix := range []string{"key", "digit"} {
+		if k, ok := strings.CutPrefix(code, prefix); ok && len(k) == 1 {
+			code = k
+		}
+	}
+	return code == c.key
+}
+
+// ShortcutInfo describes a registered shortcut
+type ShortcutInfo struct {
+	Keys        string
+	Description string
+	Global      bool
+	Element     DOMNode
+}
+
+// ShortcutConflict describes a key combination registered more than once in the same scope
+type ShortcutConflict struct {
+	Keys     string
+	Elements []DOMNode
+}
+
+type OnShortcutConflict func(ShortcutConflict)
+
+func (_ Def) OnShortcutConflict() OnShortcutConflict {
+	return func(conflict ShortcutConflict) {
+		warn("shortcut conflict: %s", conflict.Keys)
+	}
+}
+
+type shortcutEntry struct {
+	element DOMNode
+	specs   []ShortcutSpec
+}
+
+// setShortcuts registers shortcuts of element, reporting conflicts when the registered keys change
+func (a *App) setShortcuts(scope Scope, element DOMNode, specs []ShortcutSpec) {
+	if len(specs) == 0 {
+		if id, ok := elementIDOf(element); ok {
+			a.eventsLock.Lock()
+			delete(a.shortcuts, id)
+			a.eventsLock.Unlock()
+		}
+		return
+	}
+	id := a.ensureElementID(element)
+
+	var conflicts []ShortcutConflict
+	a.eventsLock.Lock()
+	if entry, ok := a.shortcuts[id]; ok && sameShortcutKeys(entry.specs, specs) {
+		// checked in previous renders
+		a.shortcuts[id] = shortcutEntry{
+			element: element,
+			specs:   specs,
+		}
+		a.eventsLock.Unlock()
+		return
+	}
+	for i, spec := range specs {
+		conflict := ShortcutConflict{
+			Keys: spec.Keys,
+		}
+		// same element
+		for _, s := range specs[:i] {
+			if s.Keys == spec.Keys && s.Global == spec.Global {
+				conflict.Elements = []DOMNode{element, element}
+				break
+			}
+		}
+		// global shortcuts of other elements
+		if spec.Global && len(conflict.Elements) == 0 {
+			for otherID, entry := range a.shortcuts {
+				if otherID == id {
+					continue
+				}
+				if slices.ContainsFunc(entry.specs, func(s ShortcutSpec) bool {
+					return s.Global && s.Keys == spec.Keys
+				}) {
+					conflict.Elements = []DOMNode{entry.element, element}
+					break
+				}
+			}
+		}
+		if len(conflict.Elements) > 0 {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	a.shortcuts[id] = shortcutEntry{
+		element: element,
+		specs:   specs,
+	}
+	a.eventsLock.Unlock()
+
+	if len(conflicts) > 0 {
+		var report OnShortcutConflict
+		scope.Assign(&report)
+		// reported with the hooks, not holding the lock, handler may call Update
+		a.pendingHooks = append(a.pendingHooks, func() {
+			for _, conflict := range conflicts {
+				report(conflict)
+			}
+		})
+	}
+}
+
+func sameShortcutKeys(a, b []ShortcutSpec) bool {
+	return slices.EqualFunc(a, b, func(x, y ShortcutSpec) bool {
+		return x.Keys == y.Keys && x.Global == y.Global
+	})
+}
+
+// Shortcuts returns the shortcuts of mounted elements, in the order of element creation
+func (a *App) Shortcuts() (ret []ShortcutInfo) {
+	a.eventsLock.RLock()
+	defer a.eventsLock.RUnlock()
+	ids := make([]int32, 0, len(a.shortcuts))
+	for id := range a.shortcuts {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		entry := a.shortcuts[id]
+		for _, spec := range entry.specs {
+			ret = append(ret, ShortcutInfo{
+				Keys:        spec.Keys,
+				Description: spec.Description,
+				Global:      spec.Global,
+				Element:     entry.element,
+			})
+		}
+	}
+	return
+}
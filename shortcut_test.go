@@ -0,0 +1,209 @@
+package domui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseKeyCombo(t *testing.T) {
+	mac := macPlatform
+	defer func() {
+		macPlatform = mac
+	}()
+
+	for _, c := range []struct {
+		keys     string
+		mac      bool
+		expected string
+	}{
+		{"ctrl+k", false, "ctrl+k"},
+		{"Shift+Ctrl+K", false, "ctrl+shift+k"},
+		{"mod+enter", false, "ctrl+enter"},
+		{"mod+enter", true, "meta+enter"},
+		{"cmd+alt+up", false, "alt+meta+arrowup"},
+		{"ctrl++", false, "ctrl+plus"},
+		{"space", false, "space"},
+		{"esc", false, "escape"},
+	} {
+		macPlatform = c.mac
+		combo, err := parseKeyCombo(c.keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if combo.String() != c.expected {
+			t.Fatalf("%s: got %s", c.keys, combo.String())
+		}
+	}
+
+	for _, keys := range []string{"", "ctrl+", "hyper+k", "ctrl++k"} {
+		if _, err := parseKeyCombo(keys); err == nil {
+			t.Fatalf("expecting error for %q", keys)
+		}
+	}
+}
+
+func TestShortcut(t *testing.T) {
+	var seq []string
+	var conflicts []ShortcutConflict
+	keydown := func(key string, code string, ctrl bool) *MemEvent {
+		return NewMemEvent("keydown", true).
+			Set("key", key).
+			Set("code", code).
+			Set("ctrlKey", ctrl).
+			Set("shiftKey", false).
+			Set("altKey", false).
+			Set("metaKey", false)
+	}
+	WithTestApp(
+		t,
+		func(app *App) {
+			inner := app.element.ChildNode(0).(*MemNode)
+
+			// inner overrides outer
+			ev := keydown("k", "KeyK", true)
+			inner.DispatchEvent(ev)
+			if len(seq) != 1 || seq[0] != "inner" || !ev.DefaultPrevented() {
+				t.Fatalf("got %v", seq)
+			}
+			// outer overrides global
+			app.element.(*MemNode).DispatchEvent(keydown("k", "KeyK", true))
+			if len(seq) != 2 || seq[1] != "outer" {
+				t.Fatalf("got %v", seq)
+			}
+			// global, matched by code
+			app.dom.(*MemDOM).Body().DispatchEvent(keydown("ĸ", "KeyK", true))
+			if len(seq) != 3 || seq[2] != "global" {
+				t.Fatalf("got %v", seq)
+			}
+			// not matched
+			ev = keydown("k", "KeyK", false)
+			inner.DispatchEvent(ev)
+			if len(seq) != 3 || ev.DefaultPrevented() {
+				t.Fatalf("got %v", seq)
+			}
+
+			// registry
+			infos := app.Shortcuts()
+			if len(infos) != 4 {
+				t.Fatalf("got %v", infos)
+			}
+			if infos[0].Keys != "ctrl+k" || infos[0].Description != "inner" || !infos[0].Element.Equal(inner) {
+				t.Fatalf("got %+v", infos[0])
+			}
+
+			// conflict
+			if len(conflicts) != 1 || conflicts[0].Keys != "ctrl+k" {
+				t.Fatalf("got %v", conflicts)
+			}
+			// not reported again if not changed
+			for i := 0; i < 3; i++ {
+				app.Update(func() bool {
+					return true
+				})
+				app.Render()
+			}
+			if len(conflicts) != 1 {
+				t.Fatalf("got %v", conflicts)
+			}
+
+			// unmounted
+			app.Update(func() bool {
+				return false
+			})
+			app.Render()
+			if infos := app.Shortcuts(); len(infos) != 0 {
+				t.Fatalf("got %v", infos)
+			}
+			app.dom.(*MemDOM).Body().DispatchEvent(keydown("k", "KeyK", true))
+			if len(seq) != 3 {
+				t.Fatalf("got %v", seq)
+			}
+		},
+		func() bool {
+			return true
+		},
+		func(app *App) OnShortcutConflict {
+			return func(conflict ShortcutConflict) {
+				conflicts = append(conflicts, conflict)
+				// not holding the lock
+				app.Update()
+			}
+		},
+		func(show bool) RootElement {
+			if !show {
+				return Div()
+			}
+			return Div(
+				P(
+					Shortcut("ctrl+k", func() {
+						seq = append(seq, "inner")
+					}).Describe("inner"),
+				),
+				Shortcut("ctrl+k", func() {
+					seq = append(seq, "outer")
+				}),
+				P(
+					GlobalShortcut("ctrl+k", func() {
+						seq = append(seq, "global")
+					}),
+				),
+				P(
+					GlobalShortcut("control+K", func() {
+						seq = append(seq, "conflict")
+					}),
+				),
+			)
+		},
+	)
+}
+
+func TestShortcutLayouts(t *testing.T) {
+	var seq []string
+	keydown := func(key string, code string, ctrl bool, shift bool) *MemEvent {
+		return NewMemEvent("keydown", true).
+			Set("key", key).
+			Set("code", code).
+			Set("ctrlKey", ctrl).
+			Set("shiftKey", shift).
+			Set("altKey", false).
+			Set("metaKey", false)
+	}
+	WithTestApp(
+		t,
+		func(app *App) {
+			element := app.element.(*MemNode)
+			// azerty
+			element.DispatchEvent(keydown("a", "KeyQ", true, false))
+			if !slices.Equal(seq, []string{"ctrl+a"}) {
+				t.Fatalf("got %v", seq)
+			}
+			element.DispatchEvent(keydown("q", "KeyA", true, false))
+			if !slices.Equal(seq, []string{"ctrl+a", "ctrl+q"}) {
+				t.Fatalf("got %v", seq)
+			}
+			// shifted digit
+			element.DispatchEvent(keydown("!", "Digit1", false, true))
+			if !slices.Equal(seq, []string{"ctrl+a", "ctrl+q", "shift+1"}) {
+				t.Fatalf("got %v", seq)
+			}
+			// non-latin layout
+			element.DispatchEvent(keydown("й", "KeyQ", true, false))
+			if !slices.Equal(seq, []string{"ctrl+a", "ctrl+q", "shift+1", "ctrl+q"}) {
+				t.Fatalf("got %v", seq)
+			}
+		},
+		func() RootElement {
+			return Div(
+				Shortcut("ctrl+a", func() {
+					seq = append(seq, "ctrl+a")
+				}),
+				Shortcut("ctrl+q", func() {
+					seq = append(seq, "ctrl+q")
+				}),
+				Shortcut("shift+1", func() {
+					seq = append(seq, "shift+1")
+				}),
+			)
+		},
+	)
+}
@@ -0,0 +1,152 @@
+package domui
+
+import (
+	"io"
+	"strings"
+
+	"github.com/reusee/dscope"
+)
+
+// RenderToString resolves RootElement from defs and renders it to an HTML string, without any DOM
+func RenderToString(defs ...any) (string, error) {
+	var b strings.Builder
+	if err := RenderTo(&b, defs...); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// RenderTo resolves RootElement from defs and writes its HTML to w
+func RenderTo(w io.Writer, defs ...any) (err error) {
+	defer recoverErr(&err)
+	defer he(&err)
+
+	defs = append(
+		defs,
+		func() Update {
+			// no reactive updates in server side rendering
+			return func(...any) {}
+		},
+		func() Navigate {
+			return func(string) {}
+		},
+	)
+	scope := dscope.New(
+		dscope.Methods(new(Def))...,
+	).Fork(
+		defs...,
+	)
+
+	var rootElement RootElement
+	scope.Assign(&rootElement)
+	node := rootNode(rootElement)
+
+	var b strings.Builder
+	node.writeHTML(&b, false)
+	_, err = io.WriteString(w, b.String())
+	ce(err)
+
+	return nil
+}
+
+// HTML returns the HTML serialization of the node, as rendered by Node.ToElement
+func (n *Node) HTML() string {
+	var b strings.Builder
+	n.writeHTML(&b, false)
+	return b.String()
+}
+
+func (n *Node) writeHTML(b *strings.Builder, raw bool) {
+	switch n.Kind {
+
+	case TagNode, RawHTMLNode:
+		// names are case-sensitive in svg and mathml, like viewBox and linearGradient
+		isHTML := n.namespace() == HTMLNamespace
+		name := func(s string) string {
+			if isHTML {
+				return strings.ToLower(s)
+			}
+			return s
+		}
+		tag := name(n.Text)
+		b.WriteString("<")
+		b.WriteString(tag)
+
+		// same attribute order as Node.ToElement produces
+		writeAttr := func(name, value string) {
+			b.WriteString(" ")
+			b.WriteString(name)
+			b.WriteString(`="`)
+			b.WriteString(attrEscaper.Replace(value))
+			b.WriteString(`"`)
+		}
+		if n.ID != "" {
+			writeAttr("id", n.ID)
+		}
+		if len(n.Classes) > 0 {
+			writeAttr("class", n.classText())
+		}
+		for _, item := range n.Attributes {
+			if v, ok := attrValue(item.Key, item.Value); ok {
+				writeAttr(name(item.Key), v)
+			}
+		}
+		if style := n.styleText(); style != "" {
+			writeAttr("style", style)
+		}
+		b.WriteString(">")
+
+		if isHTML && voidElements[tag] {
+			return
+		}
+		if n.Kind == RawHTMLNode {
+			b.WriteString(n.InnerHTML)
+		} else {
+			raw := isHTML && rawTextElements[tag]
+			for _, child := range n.childNodes {
+				child.writeHTML(b, raw)
+			}
+		}
+		b.WriteString("</")
+		b.WriteString(tag)
+		b.WriteString(">")
+
+	case TextNode:
+		if raw {
+			b.WriteString(n.Text)
+		} else {
+			b.WriteString(textEscaper.Replace(n.Text))
+		}
+
+	case PortalNode:
+		// rendered into the target on the client
+
+	case FragmentNode:
+		for _, child := range n.childNodes {
+			child.writeHTML(b, raw)
+		}
+
+	}
+}
+
+func (n *Node) classText() string {
+	names := make([]string, 0, len(n.Classes))
+	for _, item := range n.Classes {
+		names = append(names, item.Key)
+	}
+	return strings.Join(names, " ")
+}
+
+func (n *Node) styleText() string {
+	if n.Style == "" && len(n.Styles) == 0 {
+		return ""
+	}
+	// reuse the in-memory DOM style merging
+	styleNode := &MemNode{
+		styles: parseStyleText(n.Style),
+	}
+	for _, item := range n.Styles {
+		styleNode.SetStyle(item.Key, item.Value.(string))
+	}
+	return styleText(styleNode.styles)
+}
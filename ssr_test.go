@@ -0,0 +1,101 @@
+package domui
+
+import (
+	"testing"
+)
+
+func TestRenderToString(t *testing.T) {
+	type Title string
+	defs := []any{
+		func() Title {
+			return "<hello> & world"
+		},
+		func(title Title, update Update) RootElement {
+			return Div(
+				ID("main"),
+				Class("foo", "bar"),
+				Attrs("data-x", 42, "title", `"quoted"`),
+				FontSize("1rem"),
+				Style("color")("red"),
+				P(
+					Text("%s", title),
+				),
+				Tag("br")(),
+				Tag("style")(
+					Text("a > b {}"),
+				),
+				OnClick(func() {
+					update()
+				}),
+			)
+		},
+	}
+
+	html, err := RenderToString(defs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<div id="main" class="bar foo" data-x="42" title="&quot;quoted&quot;" style="color: red; font-size: 1rem;"><p>&lt;hello&gt; &amp; world</p><br><style>a > b {}</style></div>`
+	if html != expected {
+		t.Fatalf("got %s", html)
+	}
+
+	// same as client side rendering
+	WithTestApp(
+		t,
+		func(app *App) {
+			if got := app.HTML(); got != html {
+				t.Fatalf("got %s", got)
+			}
+		},
+		defs...,
+	)
+}
+
+func TestRenderToStringSVG(t *testing.T) {
+	defs := []any{
+		func() RootElement {
+			return Tag("DIV")(
+				Attr("Title")("foo"),
+				Tag("svg")(
+					Attr("viewBox")("0 0 10 10"),
+					Tag("linearGradient")(
+						Attr("gradientUnits")("userSpaceOnUse"),
+					),
+				),
+			)
+		},
+	}
+	html, err := RenderToString(defs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<div title="foo"><svg viewBox="0 0 10 10"><linearGradient gradientUnits="userSpaceOnUse"></linearGradient></svg></div>`
+	if html != expected {
+		t.Fatalf("got %s", html)
+	}
+	WithTestApp(
+		t,
+		func(app *App) {
+			if got := app.HTML(); got != html {
+				t.Fatalf("got %s", got)
+			}
+		},
+		defs...,
+	)
+}
+
+func TestRenderToStringFragmentRoot(t *testing.T) {
+	html, err := RenderToString(func() RootElement {
+		return Specs{
+			Tag("p")(Text("foo")),
+			Fragment(Text("bar"), Tag("br")()),
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if html != `<p>foo</p>bar<br>` {
+		t.Fatalf("got %s", html)
+	}
+}